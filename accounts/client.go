@@ -1,18 +1,34 @@
 package accounts
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
+)
+
+const (
+	defaultBaseURL = "https://api.accounts-bc.com"
+)
 
 type client struct {
 	httpClient *http.Client
+	baseURL    string
+	// baseURLErr is returned by every call when WithBaseURL got an invalid
+	// URL, since New has no error result.
+	baseURLErr error
 }
 
 func New(opts ...Option) Client {
 	c := &client{
 		httpClient: &http.Client{},
+		baseURL:    defaultBaseURL,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	c.baseURL, c.baseURLErr = endpoint.BaseURL(c.baseURL)
+
 	return c
 }
 
@@ -23,3 +39,11 @@ func WithHTTPClient(httpClient *http.Client) Option {
 		c.httpClient = httpClient
 	}
 }
+
+// WithBaseURL points the client at another accounts service, such as a
+// staging environment or a local test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *client) {
+		c.baseURL = baseURL
+	}
+}
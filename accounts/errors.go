@@ -0,0 +1,31 @@
+package accounts
+
+import (
+	"errors"
+
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
+)
+
+var (
+	ErrBadRequest          = errors.New("bad request")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrMethodNotAllowed    = errors.New("method not allowed")
+	ErrTooManyRequests     = errors.New("too many requests")
+	ErrInternalServerError = errors.New("internal server error")
+	ErrBadGateway          = errors.New("bad gateway")
+	ErrServiceUnavailable  = errors.New("service unavailable")
+	ErrUnexpectedStatus    = errors.New("unexpected status")
+
+	ErrInvalidBaseURL = endpoint.ErrInvalidBaseURL
+)
+
+// APIError describes a failed accounts call. Status failures wrap one of the
+// sentinels above, so errors.Is(err, ErrNotFound) keeps working.
+type APIError = apierror.Error
+
+func newAPIError(method, path string, statusCode int, body []byte, attempts int) *APIError {
+	return apierror.New(method, path, statusCode, body, attempts, statusError(statusCode))
+}
@@ -2,27 +2,11 @@ package accounts
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"encoding/json"
 	"io"
 	"net/http"
-)
-
-const (
-	baseURL = "https://api.accounts-bc.com/"
-)
 
-var (
-	ErrBadRequest          = errors.New("bad request")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrForbidden           = errors.New("forbidden")
-	ErrNotFound            = errors.New("not found")
-	ErrMethodNotAllowed    = errors.New("method not allowed")
-	ErrTooManyRequests     = errors.New("too many requests")
-	ErrInternalServerError = errors.New("internal server error")
-	ErrBadGateway          = errors.New("bad gateway")
-	ErrServiceUnavailable  = errors.New("service unavailable")
-	ErrUnexpectedStatus    = errors.New("unexpected status")
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
 )
 
 func makeRequest[T any](
@@ -33,13 +17,17 @@ func makeRequest[T any](
 	c *client,
 	marshal func(r io.Reader) error,
 ) (*T, error) {
-	fullURL := fmt.Sprintf("%s%s", baseURL, path)
+	if c.baseURLErr != nil {
+		return nil, c.baseURLErr
+	}
+
+	fullURL := c.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "text/html")
+	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -47,29 +35,8 @@ func makeRequest[T any](
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		switch resp.StatusCode {
-		case http.StatusBadRequest:
-			return nil, ErrBadRequest
-		case http.StatusUnauthorized:
-			return nil, ErrUnauthorized
-		case http.StatusForbidden:
-			return nil, ErrForbidden
-		case http.StatusNotFound:
-			return nil, ErrNotFound
-		case http.StatusMethodNotAllowed:
-			return nil, ErrMethodNotAllowed
-		case http.StatusTooManyRequests:
-			return nil, ErrTooManyRequests
-		case http.StatusInternalServerError:
-			return nil, ErrInternalServerError
-		case http.StatusBadGateway:
-			return nil, ErrBadGateway
-		case http.StatusServiceUnavailable:
-			return nil, ErrServiceUnavailable
-		default:
-			return nil, ErrUnexpectedStatus
-		}
+	if statusError(resp.StatusCode) != nil {
+		return nil, newAPIError(method, path, resp.StatusCode, apierror.ReadBody(resp.Body), 1)
 	}
 
 	if marshal != nil {
@@ -77,5 +44,66 @@ func makeRequest[T any](
 		return nil, err
 	}
 
-	return nil, nil
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var alert alertResponse
+	if err := json.Unmarshal(raw, &alert); err == nil && alert.HasError {
+		return nil, &APIError{
+			Method:       method,
+			Path:         path,
+			StatusCode:   resp.StatusCode,
+			HasError:     true,
+			AlertMessage: alert.AlertMessage,
+			Body:         apierror.Truncate(raw),
+			Attempts:     1,
+		}
+	}
+
+	var data T
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// alertResponse holds the business error fields BetConstruct services set on
+// an otherwise successful response.
+type alertResponse struct {
+	HasError     bool
+	AlertMessage string
+}
+
+// statusError maps an HTTP status code to a sentinel error. It returns nil for
+// any 2xx status.
+func statusError(statusCode int) error {
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusInternalServerError:
+		return ErrInternalServerError
+	case http.StatusBadGateway:
+		return ErrBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	default:
+		return ErrUnexpectedStatus
+	}
 }
@@ -0,0 +1,81 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type echoResponse struct {
+	Value string `json:"value"`
+}
+
+func newTestClient(t *testing.T, h http.Handler) *client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return New(WithBaseURL(srv.URL)).(*client)
+}
+
+func TestMakeRequest(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"value":"ok"}`))
+	}))
+
+	resp, err := makeRequest[echoResponse](context.Background(), http.MethodGet, "/echo", nil, c, nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.Value != "ok" {
+		t.Fatalf("got %q, want ok", resp.Value)
+	}
+}
+
+func TestMakeRequest_APIError(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		}))
+
+		_, err := makeRequest[echoResponse](context.Background(), http.MethodPost, "/echo", nil, c, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("got error %v, want %v", err, ErrServiceUnavailable)
+		}
+		if apiErr.Method != http.MethodPost || apiErr.Path != "/echo" || apiErr.HasError {
+			t.Fatalf("got %+v, want a POST /echo status failure", apiErr)
+		}
+		if apiErr.Body != "maintenance\n" {
+			t.Fatalf("got body %q, want maintenance", apiErr.Body)
+		}
+	})
+
+	t.Run("has error", func(t *testing.T) {
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"HasError":true,"AlertMessage":"Account locked"}`))
+		}))
+
+		_, err := makeRequest[echoResponse](context.Background(), http.MethodPost, "/echo", nil, c, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !apiErr.HasError || apiErr.AlertMessage != "Account locked" || apiErr.StatusCode != http.StatusOK {
+			t.Fatalf("got %+v, want HasError with alert message", apiErr)
+		}
+	})
+}
+
+func TestNew_InvalidBaseURL(t *testing.T) {
+	c := New(WithBaseURL("accounts.local")).(*client)
+
+	_, err := makeRequest[echoResponse](context.Background(), http.MethodGet, "/echo", nil, c, nil)
+	if !errors.Is(err, ErrInvalidBaseURL) {
+		t.Fatalf("got error %v, want %v", err, ErrInvalidBaseURL)
+	}
+}
@@ -6,6 +6,7 @@ type Client interface {
 	ListTransactions(ctx context.Context, req ListTransactionsRequest) ([]Transaction, error)
 	ListDeposits(ctx context.Context, in ListDepositsInput) (*ListDepositsOutput, error)
 	ListWithdrawals(ctx context.Context, req ListWithdrawalsRequest) ([]Withdrawal, error)
+	ListWithdrawalsWithTotals(ctx context.Context, req ListWithdrawalsRequest) (*ListWithdrawalsOutput, error)
 
 	ListRegisteredPlayers(ctx context.Context, req ListRegisteredPlayersRequest) ([]RegisteredPlayer, error)
 	ListPlayers(ctx context.Context, req ListPlayersRequest) ([]*ListPlayersPlayer, error)
@@ -0,0 +1,709 @@
+// Package backofficefake provides an in-memory implementation of
+// backoffice.Client for unit tests.
+//
+// Unlike a hand-written mock, the fake keeps state and follows the semantics
+// of the real backoffice: a bonus added with AddBonusToPlayer is listed by
+// ListPlayerBonuses, CancelPlayerBonus cancels it, a restriction saved with
+// SaveClientRestriction is returned by GetClientRestriction and a promo code
+// created with CreatePromoCode is listed by ListPromoCodes.
+package backofficefake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+// Player is a seeded player together with the data the backoffice keeps
+// about it outside of backoffice.Player.
+type Player struct {
+	backoffice.Player
+	CreatedAt      time.Time
+	Balance        float64
+	PlayerCategory *backoffice.PlayerCategory
+	FirstDepositAt time.Time
+	KPI            backoffice.PlayerKPI
+	Restriction    backoffice.GetClientRestrictionResult
+	CasinoGames    []backoffice.PlayerCasinoGame
+}
+
+type sportBet struct {
+	playerID backoffice.PlayerID
+	bet      backoffice.SportBet
+}
+
+type Client struct {
+	// Location is the time zone used to match calendar days, as set with
+	// backoffice.WithTimeLocation on a real client. It defaults to UTC.
+	Location *time.Location
+
+	mu               sync.Mutex
+	nextID           int64
+	players          []*Player
+	deposits         []backoffice.Deposit
+	transactions     []backoffice.Transaction
+	withdrawals      []backoffice.Withdrawal
+	bets             []sportBet
+	sportKindReports []backoffice.SportKindReport
+	bonuses          map[backoffice.PlayerID][]backoffice.PlayerBonus
+	paymentMethods   []backoffice.PaymentMethod
+	domains          []backoffice.PartnerDomain
+	promoCodes       []backoffice.PromoCode
+	promoCodeUsages  []backoffice.PromoCodeUsage
+	errs             map[string]error
+}
+
+var _ backoffice.Client = (*Client)(nil)
+
+func New() *Client {
+	return &Client{
+		Location: time.UTC,
+		nextID:   1000,
+		bonuses:  make(map[backoffice.PlayerID][]backoffice.PlayerBonus),
+		errs:     make(map[string]error),
+	}
+}
+
+// InjectError makes the next call to method, such as "GetPlayer", return err
+// without touching any state.
+func (c *Client) InjectError(method string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs[method] = err
+}
+
+// takeError returns and clears the injected error for method, if any. The
+// caller must hold c.mu.
+func (c *Client) takeError(method string) error {
+	if err, ok := c.errs[method]; ok {
+		delete(c.errs, method)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) id() int64 {
+	c.nextID++
+	return c.nextID
+}
+
+func (c *Client) player(id backoffice.PlayerID) (*Player, error) {
+	for _, p := range c.players {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("player %d: %w", id, backoffice.ErrNotFound)
+}
+
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// outOfBounds reports whether amount falls outside the amount bounds of req,
+// preferring the exact bounds the way the real client does.
+func outOfBounds(amount backoffice.Decimal, req backoffice.ListWithdrawalsRequest) bool {
+	lo, hi := amountBound(req.MinMoney, req.MinAmount), amountBound(req.MaxMoney, req.MaxAmount)
+	return (!lo.IsZero() && amount.Cmp(lo) < 0) || (!hi.IsZero() && amount.Cmp(hi) > 0)
+}
+
+func amountBound(exact backoffice.Decimal, f float64) backoffice.Decimal {
+	if !exact.IsZero() {
+		return exact
+	}
+	d, _ := backoffice.DecimalFromFloat(f)
+	return d
+}
+
+func page[T any](items []T, skip, limit int) []T {
+	if skip >= len(items) {
+		return []T{}
+	}
+	items = items[skip:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return slices.Clone(items)
+}
+
+func sameDay(a, b time.Time, loc *time.Location) bool {
+	ay, am, ad := a.In(loc).Date()
+	by, bm, bd := b.In(loc).Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func (c *Client) ListTransactions(ctx context.Context, req backoffice.ListTransactionsRequest) ([]backoffice.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListTransactions"); err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	if req.FromDate != nil {
+		from = req.FromDate.Time
+	}
+	if req.ToDate != nil {
+		to = req.ToDate.Time
+	}
+
+	transactions := make([]backoffice.Transaction, 0)
+	for _, t := range c.transactions {
+		if inRange(time.Time(t.CreatedAt), from, to) {
+			transactions = append(transactions, t)
+		}
+	}
+	return page(transactions, req.Offset, req.MaxRows), nil
+}
+
+func (c *Client) ListDeposits(ctx context.Context, in backoffice.ListDepositsInput) (*backoffice.ListDepositsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListDeposits"); err != nil {
+		return nil, err
+	}
+
+	deposits := make([]backoffice.Deposit, 0)
+	for _, d := range c.deposits {
+		if inRange(d.CreatedAt, in.FromDate, in.ToDate) {
+			deposits = append(deposits, d)
+		}
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	return &backoffice.ListDepositsOutput{
+		Deposits: page(deposits, in.Offset, limit),
+		Count:    len(deposits),
+	}, nil
+}
+
+func (c *Client) ListWithdrawals(ctx context.Context, req backoffice.ListWithdrawalsRequest) ([]backoffice.Withdrawal, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListWithdrawals"); err != nil {
+		return nil, err
+	}
+
+	return page(c.listWithdrawals(req), req.Offset, req.Limit), nil
+}
+
+func (c *Client) ListWithdrawalsWithTotals(ctx context.Context, req backoffice.ListWithdrawalsRequest) (*backoffice.ListWithdrawalsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListWithdrawalsWithTotals"); err != nil {
+		return nil, err
+	}
+
+	withdrawals := c.listWithdrawals(req)
+	out := &backoffice.ListWithdrawalsOutput{
+		Withdrawals: page(withdrawals, req.Offset, req.Limit),
+	}
+	for _, w := range withdrawals {
+		total, err := out.Totals.Money.Add(backoffice.NewMoney(w.Money.Amount, ""))
+		if err != nil {
+			return nil, err
+		}
+		out.Totals.Count++
+		out.Totals.Money = total
+	}
+	out.Totals.Amount = out.Totals.Money.Amount.Float64()
+	return out, nil
+}
+
+func (c *Client) listWithdrawals(req backoffice.ListWithdrawalsRequest) []backoffice.Withdrawal {
+	withdrawals := make([]backoffice.Withdrawal, 0)
+	for _, w := range c.withdrawals {
+		switch {
+		case req.ID != 0 && w.ID != req.ID,
+			req.PlayerID != 0 && w.PlayerID != req.PlayerID,
+			req.PaymentSystemID != 0 && w.PaymentSystemID != req.PaymentSystemID,
+			len(req.Statuses) > 0 && !slices.Contains(req.Statuses, w.Status),
+			outOfBounds(w.Money.Amount, req),
+			!inRange(time.Time(w.RequestedAt), req.FromDate, req.ToDate):
+			continue
+		}
+		withdrawals = append(withdrawals, w)
+	}
+	return withdrawals
+}
+
+func (c *Client) ListRegisteredPlayers(ctx context.Context, req backoffice.ListRegisteredPlayersRequest) ([]backoffice.RegisteredPlayer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListRegisteredPlayers"); err != nil {
+		return nil, err
+	}
+
+	players := make([]backoffice.RegisteredPlayer, 0)
+	for _, p := range c.players {
+		if !sameDay(p.CreatedAt, req.Date, c.Location) {
+			continue
+		}
+		players = append(players, backoffice.RegisteredPlayer{
+			ID:        p.ID,
+			CreatedAt: backoffice.DateTime(p.CreatedAt),
+			Username:  p.Username,
+			FullName:  p.FirstName + " " + p.LastName,
+		})
+	}
+	return players, nil
+}
+
+func (c *Client) ListPlayers(ctx context.Context, req backoffice.ListPlayersRequest) ([]*backoffice.ListPlayersPlayer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPlayers"); err != nil {
+		return nil, err
+	}
+
+	players := make([]*backoffice.ListPlayersPlayer, 0)
+	for _, p := range c.players {
+		if req.Username != "" && p.Username != req.Username {
+			continue
+		}
+		if req.Phone != "" && p.Phone != req.Phone {
+			continue
+		}
+		if !inRange(p.CreatedAt, req.FromRegistrationDate, req.ToRegistrationDate) {
+			continue
+		}
+		players = append(players, &backoffice.ListPlayersPlayer{
+			ID:             p.ID,
+			CreatedAt:      p.CreatedAt,
+			Username:       p.Username,
+			FirstName:      p.FirstName,
+			MiddleName:     p.MiddleName,
+			LastName:       p.LastName,
+			Balance:        p.Balance,
+			PlayerCategory: p.PlayerCategory,
+			FirstDepositAt: p.FirstDepositAt,
+		})
+	}
+	return page(players, req.Offset, req.MaxRows), nil
+}
+
+func (c *Client) GetPlayer(ctx context.Context, playerID backoffice.PlayerID) (*backoffice.Player, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("GetPlayer"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.player(playerID)
+	if err != nil {
+		return nil, err
+	}
+	player := p.Player
+	return &player, nil
+}
+
+func (c *Client) GetPlayerKPI(ctx context.Context, playerID backoffice.PlayerID) (*backoffice.PlayerKPI, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("GetPlayerKPI"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.player(playerID)
+	if err != nil {
+		return nil, err
+	}
+	kpi := p.KPI
+	kpi.PlayerID = p.ID
+	return &kpi, nil
+}
+
+func (c *Client) GetClientRestriction(ctx context.Context, playerID backoffice.PlayerID) (*backoffice.GetClientRestrictionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("GetClientRestriction"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.player(playerID)
+	if err != nil {
+		return nil, err
+	}
+	restriction := p.Restriction
+	restriction.PlayerID = p.ID
+	return &restriction, nil
+}
+
+func (c *Client) SaveClientRestriction(ctx context.Context, req backoffice.SaveClientRestrictionRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("SaveClientRestriction"); err != nil {
+		return err
+	}
+
+	p, err := c.player(req.PlayerID)
+	if err != nil {
+		return err
+	}
+	updatedAt := backoffice.DateTime(time.Now())
+	p.Restriction = backoffice.GetClientRestrictionResult{
+		PlayerID:                req.PlayerID,
+		CanLogin:                req.CanLogin,
+		CanBet:                  req.CanBet,
+		CanDeposit:              req.CanDeposit,
+		CanWithdraw:             req.CanWithdraw,
+		CanIncreaseLimit:        req.CanIncreaseLimit,
+		CanClaimBonus:           req.CanClaimBonus,
+		CanCasinoLogin:          req.CanCasinoLogin,
+		CanUploadDocument:       req.CanUploadDocument,
+		IsWithdrawalAutoConfirm: p.Restriction.IsWithdrawalAutoConfirm,
+		UpdatedAt:               &updatedAt,
+	}
+	return nil
+}
+
+func (c *Client) AddPaymentToPlayer(ctx context.Context, req backoffice.AddPaymentToPlayerRequest) error {
+	// Reuse the real validation of the request type.
+	if _, err := req.MarshalJSON(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("AddPaymentToPlayer"); err != nil {
+		return err
+	}
+
+	p, err := c.player(req.PlayerID)
+	if err != nil {
+		return err
+	}
+	money := backoffice.Money{Currency: req.Currency}
+	if req.Money != nil {
+		money = *req.Money
+	} else if money.Amount, err = backoffice.DecimalFromFloat(req.Amount); err != nil {
+		return err
+	}
+	p.Balance += money.Amount.Float64()
+
+	note := req.Note
+	c.transactions = append(c.transactions, backoffice.Transaction{
+		ID:        c.id(),
+		Amount:    money.Amount.Float64(),
+		Money:     money,
+		PlayerID:  req.PlayerID,
+		Type:      backoffice.TransactionTypeCorrectionUp,
+		Note:      &note,
+		CreatedAt: backoffice.DateTime(time.Now()),
+	})
+	return nil
+}
+
+func (c *Client) ListPlayerTransactions(ctx context.Context, req backoffice.ListPlayerTransactionsRequest) ([]backoffice.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPlayerTransactions"); err != nil {
+		return nil, err
+	}
+
+	// The backoffice filters player transactions by whole days.
+	to := req.ToDate.Time
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	transactions := make([]backoffice.Transaction, 0)
+	for _, t := range c.transactions {
+		if req.PlayerID != 0 && t.PlayerID != req.PlayerID {
+			continue
+		}
+		if inRange(time.Time(t.CreatedAt), req.FromDate.Time, to) {
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+func (c *Client) ListPlayerCasinoGames(ctx context.Context, req backoffice.ListPlayerCasinoGamesRequest) ([]backoffice.PlayerCasinoGame, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPlayerCasinoGames"); err != nil {
+		return nil, err
+	}
+
+	p, err := c.player(req.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(p.CasinoGames), nil
+}
+
+func (c *Client) ListPlayerBonuses(ctx context.Context, playerID backoffice.PlayerID) ([]backoffice.PlayerBonus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPlayerBonuses"); err != nil {
+		return nil, err
+	}
+
+	bonuses := slices.Clone(c.bonuses[playerID])
+	if bonuses == nil {
+		bonuses = []backoffice.PlayerBonus{}
+	}
+	return bonuses, nil
+}
+
+func (c *Client) AddBonusToPlayer(ctx context.Context, req backoffice.AddBonusToPlayerRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("AddBonusToPlayer"); err != nil {
+		return err
+	}
+
+	if _, err := c.player(req.PlayerID); err != nil {
+		return err
+	}
+	amount, err := backoffice.DecimalFromFloat(req.Amount)
+	if err != nil {
+		return err
+	}
+	c.bonuses[req.PlayerID] = append(c.bonuses[req.PlayerID], backoffice.PlayerBonus{
+		ID:        c.id(),
+		Amount:    req.Amount,
+		Money:     backoffice.NewMoney(amount, ""),
+		CreatedAt: time.Now().UTC(),
+		Result:    backoffice.BonusResultPending,
+		State:     backoffice.BonusStatePending,
+		BonusID:   req.BonusID,
+	})
+	return nil
+}
+
+func (c *Client) CancelPlayerBonus(ctx context.Context, req backoffice.CancelPlayerBonusRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("CancelPlayerBonus"); err != nil {
+		return err
+	}
+
+	for _, bonuses := range c.bonuses {
+		for i := range bonuses {
+			if bonuses[i].ID == req.BonusID {
+				bonuses[i].Result = backoffice.BonusResultCancelled
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("bonus %d: %w", req.BonusID, backoffice.ErrNotFound)
+}
+
+func (c *Client) GetSportKindReport(ctx context.Context, req backoffice.GetSportKindReportRequest) ([]backoffice.SportKindReport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("GetSportKindReport"); err != nil {
+		return nil, err
+	}
+
+	reports := slices.Clone(c.sportKindReports)
+	if reports == nil {
+		reports = []backoffice.SportKindReport{}
+	}
+	return reports, nil
+}
+
+func (c *Client) sportBets(req backoffice.ListSportBetsRequest) []backoffice.SportBet {
+	var from, to time.Time
+	if req.FromDate != nil {
+		from = req.FromDate.Time
+	}
+	if req.ToDate != nil {
+		to = req.ToDate.Time
+	}
+
+	bets := make([]backoffice.SportBet, 0)
+	for _, b := range c.bets {
+		if req.PlayerID != nil && b.playerID != *req.PlayerID {
+			continue
+		}
+		if req.Status != nil && b.bet.Status != *req.Status {
+			continue
+		}
+		if inRange(time.Time(b.bet.CreatedAt), from, to) {
+			bets = append(bets, b.bet)
+		}
+	}
+	return bets
+}
+
+func (c *Client) ListSportBets(ctx context.Context, req backoffice.ListSportBetsRequest) ([]backoffice.SportBet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListSportBets"); err != nil {
+		return nil, err
+	}
+
+	return c.sportBets(req), nil
+}
+
+func (c *Client) GetBetHistory(ctx context.Context, req backoffice.ListSportBetsRequest) (*backoffice.GetBetHistoryResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("GetBetHistory"); err != nil {
+		return nil, err
+	}
+
+	bets := c.sportBets(req)
+	var totals backoffice.SportBetTotals
+	for _, b := range bets {
+		totals.EquivalentAmountSum += b.Amount
+		if b.Status == backoffice.SportBetStatusWon {
+			totals.EquivalentWinningSum += b.Amount * b.Price
+		}
+	}
+	return &backoffice.GetBetHistoryResult{Bets: bets, Totals: totals}, nil
+}
+
+func (c *Client) ListPaymentMethods(ctx context.Context, req backoffice.ListPaymentMethodsRequest) ([]*backoffice.PaymentMethod, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPaymentMethods"); err != nil {
+		return nil, err
+	}
+
+	methods := make([]*backoffice.PaymentMethod, 0)
+	for _, m := range c.paymentMethods {
+		if req.Status == backoffice.ListPaymentMethodsStatusActive && !m.IsActive.Bool() {
+			continue
+		}
+		if req.Status == backoffice.ListPaymentMethodsStatusInactive && m.IsActive.Bool() {
+			continue
+		}
+		methods = append(methods, &m)
+	}
+	return methods, nil
+}
+
+func (c *Client) FindPaymentMethodByName(ctx context.Context, name string) (*backoffice.PaymentMethod, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("FindPaymentMethodByName"); err != nil {
+		return nil, err
+	}
+
+	for _, m := range c.paymentMethods {
+		if m.Name == name {
+			return &m, nil
+		}
+	}
+	return nil, backoffice.ErrPaymentMethodNotFound
+}
+
+func (c *Client) UpdatePaymentMethod(ctx context.Context, method backoffice.PaymentMethod) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("UpdatePaymentMethod"); err != nil {
+		return err
+	}
+
+	for i := range c.paymentMethods {
+		if c.paymentMethods[i].ID == method.ID {
+			c.paymentMethods[i] = method
+			return nil
+		}
+	}
+	return backoffice.ErrPaymentMethodNotFound
+}
+
+func (c *Client) ListPartnerDomains(ctx context.Context, partnerID backoffice.PartnerID) ([]backoffice.PartnerDomain, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPartnerDomains"); err != nil {
+		return nil, err
+	}
+
+	domains := make([]backoffice.PartnerDomain, 0)
+	for _, d := range c.domains {
+		if partnerID == 0 || d.PartnerID == partnerID {
+			domains = append(domains, d)
+		}
+	}
+	return domains, nil
+}
+
+func (c *Client) SetActiveDomain(ctx context.Context, domainID int32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("SetActiveDomain"); err != nil {
+		return err
+	}
+
+	for i := range c.domains {
+		if c.domains[i].ID == domainID {
+			c.domains[i].Status = backoffice.PartnerDomainStatusActive
+			return nil
+		}
+	}
+	return fmt.Errorf("domain %d: %w", domainID, backoffice.ErrNotFound)
+}
+
+func (c *Client) CreatePromoCode(ctx context.Context, in backoffice.CreatePromoCodeInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("CreatePromoCode"); err != nil {
+		return err
+	}
+
+	for _, p := range c.promoCodes {
+		if p.Code == in.Code {
+			return fmt.Errorf("promo code %s already exists", in.Code)
+		}
+	}
+	c.promoCodes = append(c.promoCodes, backoffice.PromoCode{
+		ID:        c.id(),
+		Code:      in.Code,
+		CreatedAt: time.Now().UTC(),
+		StartDate: in.StartDate,
+		EndDate:   in.EndDate,
+		MaxUses:   in.MaxUses,
+		Type:      in.Type,
+	})
+	return nil
+}
+
+func (c *Client) ListPromoCodes(ctx context.Context, in backoffice.ListPromoCodesInput) ([]backoffice.PromoCode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPromoCodes"); err != nil {
+		return nil, err
+	}
+
+	promoCodes := make([]backoffice.PromoCode, 0)
+	for _, p := range c.promoCodes {
+		if in.Code == "" || p.Code == in.Code {
+			promoCodes = append(promoCodes, p)
+		}
+	}
+	return page(promoCodes, in.Offset, in.Limit), nil
+}
+
+func (c *Client) ListPromoCodeUsages(ctx context.Context, in backoffice.ListPromoCodeUsagesInput) ([]*backoffice.PromoCodeUsage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.takeError("ListPromoCodeUsages"); err != nil {
+		return nil, err
+	}
+
+	usages := make([]*backoffice.PromoCodeUsage, 0)
+	for _, u := range c.promoCodeUsages {
+		if in.PromoCodeID != 0 && u.PromoCodeID != in.PromoCodeID {
+			continue
+		}
+		if in.PromoCode != "" && u.PromoCode != in.PromoCode {
+			continue
+		}
+		if inRange(u.CreatedAt, in.StartDate, in.EndDate) {
+			usages = append(usages, &u)
+		}
+	}
+	return usages, nil
+}
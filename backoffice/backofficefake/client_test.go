@@ -0,0 +1,91 @@
+package backofficefake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+	"github.com/extrasoftorg/betconstruct/backoffice/backofficefake"
+)
+
+func TestClient_Bonuses(t *testing.T) {
+	c := backofficefake.New()
+	ctx := context.Background()
+	c.AddPlayer(backofficefake.Player{Player: backoffice.Player{ID: 42}})
+
+	if err := c.AddBonusToPlayer(ctx, backoffice.AddBonusToPlayerRequest{PlayerID: 42, BonusID: 7, Amount: 50}); err != nil {
+		t.Fatalf("failed to add bonus: %v", err)
+	}
+	bonuses, err := c.ListPlayerBonuses(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to list bonuses: %v", err)
+	}
+	if len(bonuses) != 1 || bonuses[0].BonusID != 7 || bonuses[0].Result != backoffice.BonusResultPending {
+		t.Fatalf("got bonuses %+v, want one pending bonus 7", bonuses)
+	}
+
+	if err := c.CancelPlayerBonus(ctx, backoffice.CancelPlayerBonusRequest{BonusID: bonuses[0].ID}); err != nil {
+		t.Fatalf("failed to cancel bonus: %v", err)
+	}
+	bonuses, _ = c.ListPlayerBonuses(ctx, 42)
+	if bonuses[0].Result != backoffice.BonusResultCancelled {
+		t.Fatalf("got bonus result %s, want cancelled", bonuses[0].Result)
+	}
+
+	if err := c.AddBonusToPlayer(ctx, backoffice.AddBonusToPlayerRequest{PlayerID: 1}); !errors.Is(err, backoffice.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, backoffice.ErrNotFound)
+	}
+}
+
+func TestClient_Restriction(t *testing.T) {
+	c := backofficefake.New()
+	ctx := context.Background()
+	c.AddPlayer(backofficefake.Player{Player: backoffice.Player{ID: 42}})
+
+	if err := c.SaveClientRestriction(ctx, backoffice.SaveClientRestrictionRequest{PlayerID: 42, CanLogin: true, CanDeposit: true}); err != nil {
+		t.Fatalf("failed to save restriction: %v", err)
+	}
+	restriction, err := c.GetClientRestriction(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to get restriction: %v", err)
+	}
+	if !restriction.CanLogin || !restriction.CanDeposit || restriction.CanWithdraw || restriction.UpdatedAt == nil {
+		t.Fatalf("got restriction %+v, want the saved one", restriction)
+	}
+}
+
+func TestClient_PromoCodes(t *testing.T) {
+	c := backofficefake.New()
+	ctx := context.Background()
+
+	in := backoffice.CreatePromoCodeInput{Code: "WELCOME", MaxUses: 10, StartDate: time.Now(), EndDate: time.Now().AddDate(0, 1, 0)}
+	if err := c.CreatePromoCode(ctx, in); err != nil {
+		t.Fatalf("failed to create promo code: %v", err)
+	}
+	if err := c.CreatePromoCode(ctx, in); err == nil {
+		t.Fatalf("created a duplicate promo code")
+	}
+
+	promoCodes, err := c.ListPromoCodes(ctx, backoffice.ListPromoCodesInput{Code: "WELCOME"})
+	if err != nil {
+		t.Fatalf("failed to list promo codes: %v", err)
+	}
+	if len(promoCodes) != 1 || promoCodes[0].MaxUses != 10 {
+		t.Fatalf("got promo codes %+v, want WELCOME", promoCodes)
+	}
+}
+
+func TestClient_InjectError(t *testing.T) {
+	c := backofficefake.New()
+	c.AddPlayer(backofficefake.Player{Player: backoffice.Player{ID: 42}})
+	c.InjectError("GetPlayer", backoffice.ErrServiceUnavailable)
+
+	if _, err := c.GetPlayer(context.Background(), 42); !errors.Is(err, backoffice.ErrServiceUnavailable) {
+		t.Fatalf("got error %v, want %v", err, backoffice.ErrServiceUnavailable)
+	}
+	if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+		t.Fatalf("injected error outlived one call: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package backofficefake
+
+import (
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+func (c *Client) AddPlayer(p Player) {
+	kpi := &p.KPI
+	fillMoney(&kpi.TotalDepositAmount, &kpi.TotalDepositMoney)
+	fillMoney(&kpi.TotalWithdrawalAmount, &kpi.TotalWithdrawalMoney)
+	fillMoney(&kpi.LastDepositAmount, &kpi.LastDepositMoney)
+	fillMoney(&kpi.LastWithdrawalAmount, &kpi.LastWithdrawalMoney)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.players = append(c.players, &p)
+}
+
+// Player returns the current state of a seeded player.
+func (c *Client) Player(id backoffice.PlayerID) (Player, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, err := c.player(id)
+	if err != nil {
+		return Player{}, false
+	}
+	return *p, true
+}
+
+func (c *Client) AddDeposit(d backoffice.Deposit) {
+	fillMoney(&d.Amount, &d.Money)
+	if d.Money.Currency == "" {
+		d.Money.Currency = d.Currency
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deposits = append(c.deposits, d)
+}
+
+func (c *Client) AddTransaction(t backoffice.Transaction) {
+	fillMoney(&t.Amount, &t.Money)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transactions = append(c.transactions, t)
+}
+
+func (c *Client) AddWithdrawal(w backoffice.Withdrawal) {
+	fillMoney(&w.Amount, &w.Money)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.withdrawals = append(c.withdrawals, w)
+}
+
+func (c *Client) AddSportBet(playerID backoffice.PlayerID, b backoffice.SportBet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bets = append(c.bets, sportBet{playerID: playerID, bet: b})
+}
+
+func (c *Client) AddSportKindReport(r backoffice.SportKindReport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sportKindReports = append(c.sportKindReports, r)
+}
+
+func (c *Client) AddPlayerBonus(playerID backoffice.PlayerID, b backoffice.PlayerBonus) {
+	fillMoney(&b.Amount, &b.Money)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bonuses[playerID] = append(c.bonuses[playerID], b)
+}
+
+func (c *Client) AddPaymentMethod(m backoffice.PaymentMethod) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paymentMethods = append(c.paymentMethods, m)
+}
+
+func (c *Client) AddPartnerDomain(d backoffice.PartnerDomain) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.domains = append(c.domains, d)
+}
+
+func (c *Client) AddPromoCode(p backoffice.PromoCode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.promoCodes = append(c.promoCodes, p)
+}
+
+func (c *Client) AddPromoCodeUsage(u backoffice.PromoCodeUsage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.promoCodeUsages = append(c.promoCodeUsages, u)
+}
+
+// fillMoney completes whichever of the float64 and Money amounts a seed left
+// empty, the way the real client populates both.
+func fillMoney(amount *float64, m *backoffice.Money) {
+	switch {
+	case m.Amount.IsZero():
+		m.Amount, _ = backoffice.DecimalFromFloat(*amount)
+	case *amount == 0:
+		*amount = m.Amount.Float64()
+	}
+}
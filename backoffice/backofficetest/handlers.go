@@ -0,0 +1,689 @@
+package backofficetest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+var routes = map[string]handlerFunc{
+	"GET /Client/GetClientById":                           getClientByID,
+	"POST /Client/GetClients":                             getClients,
+	"GET /Client/GetClientKpi":                            getClientKPI,
+	"POST /Client/GetClientRegistrationStatisticsDetails": getClientRegistrations,
+	"GET /Client/GetClientRestriction":                    getClientRestriction,
+	"POST /Client/SaveClientRestriction":                  saveClientRestriction,
+	"POST /Client/CreateClientPaymentDocument":            createClientPaymentDocument,
+	"POST /Client/GetClientTransactionsV1":                getClientTransactions,
+	"POST /Client/GetClientCasinoGames":                   getClientCasinoGames,
+	"POST /Client/GetClientBonuses":                       getClientBonuses,
+	"POST /Client/AddClientToBonus":                       addClientToBonus,
+	"POST /Client/CancelWageringBonusAsync":               cancelWageringBonus,
+	"POST /Client/GetClientWithdrawalRequestsWithTotals":  getWithdrawals,
+	"POST /Financial/GetDepositsWithdrawalsWithPaging":    getDeposits,
+	"POST /Financial/GetDocumentsWithPaging":              getDocuments,
+	"POST /Report/GetBetHistory":                          getBetHistory,
+	"POST /Report/GetSportKindReport":                     getSportKindReport,
+	"POST /Report/GetClientPromoCodes":                    getClientPromoCodes,
+	"POST /Reference/PaymentAPI":                          paymentAPI,
+	"GET /Reference/GetPartnerDomains":                    getPartnerDomains,
+	"POST /Reference/SetActiveDomain":                     setActiveDomain,
+	"POST /Reference/SavePromoCodeWithItemsAsync":         savePromoCode,
+	"POST /Reference/GetPromoCodesPagingAsync":            getPromoCodes,
+}
+
+func getClientByID(s *Server, r *http.Request, body []byte) (any, error) {
+	id, err := queryID(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, err
+	}
+	p := s.player(backoffice.PlayerID(id))
+	if p == nil {
+		return nil, errNotFound
+	}
+	return p.Player, nil
+}
+
+func getClients(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		MinCreatedLocal *string `json:"MinCreatedLocal"`
+		MaxCreatedLocal *string `json:"MaxCreatedLocal"`
+		MaxRows         int     `json:"MaxRows"`
+		SkeepRows       int     `json:"SkeepRows"`
+		Login           string  `json:"Login"`
+		Phone           string  `json:"Phone"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.MinCreatedLocal, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.MaxCreatedLocal, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	type player struct {
+		ID             backoffice.PlayerID `json:"Id"`
+		CreatedAt      string              `json:"CreatedLocalDate"`
+		Username       string              `json:"Login"`
+		FirstName      string              `json:"FirstName"`
+		MiddleName     string              `json:"MiddleName"`
+		LastName       string              `json:"LastName"`
+		Balance        float64             `json:"Balance"`
+		PlayerCategory int                 `json:"SportsbookProfileId"`
+		FirstDepositAt *string             `json:"FirstDepositDateLocal"`
+	}
+	players := make([]player, 0)
+	for _, p := range s.players {
+		if req.Login != "" && p.Username != req.Login {
+			continue
+		}
+		if req.Phone != "" && p.Phone != req.Phone {
+			continue
+		}
+		if !inRange(p.CreatedAt, from, to) {
+			continue
+		}
+		players = append(players, player{
+			ID:             p.ID,
+			CreatedAt:      s.local(p.CreatedAt),
+			Username:       p.Username,
+			FirstName:      p.FirstName,
+			MiddleName:     p.MiddleName,
+			LastName:       p.LastName,
+			Balance:        p.Balance,
+			PlayerCategory: playerCategoryID(p.PlayerCategory),
+			FirstDepositAt: s.localPtr(p.FirstDepositAt, localLayout),
+		})
+	}
+
+	return map[string]any{
+		"Count":   len(players),
+		"Objects": page(players, req.SkeepRows, req.MaxRows),
+	}, nil
+}
+
+func getClientKPI(s *Server, r *http.Request, body []byte) (any, error) {
+	id, err := queryID(r.URL.Query().Get("id"))
+	if err != nil {
+		return nil, err
+	}
+	p := s.player(backoffice.PlayerID(id))
+	if p == nil {
+		return nil, errNotFound
+	}
+
+	kpi := p.KPI
+	return map[string]any{
+		"ClientId":                kpi.PlayerID,
+		"DepositAmount":           wireAmount(kpi.TotalDepositAmount, kpi.TotalDepositMoney),
+		"DepositCount":            kpi.DepositCount,
+		"WithdrawalAmount":        wireAmount(kpi.TotalWithdrawalAmount, kpi.TotalWithdrawalMoney),
+		"WithdrawalCount":         kpi.WithdrawalCount,
+		"LastDepositAmount":       wireAmount(kpi.LastDepositAmount, kpi.LastDepositMoney),
+		"LastWithdrawalAmount":    wireAmount(kpi.LastWithdrawalAmount, kpi.LastWithdrawalMoney),
+		"CurrencyId":              kpi.TotalDepositMoney.Currency,
+		"FirstDepositTimeLocal":   s.localPtr(kpi.FirstDepositAt, localLayout),
+		"LastDepositTimeLocal":    s.localPtr(kpi.LastDepositAt, localLayout),
+		"LastWithdrawalTimeLocal": s.localPtr(kpi.LastWithdrawalAt, microLayout),
+		"LastSportBetTimeLocal":   s.localPtr(kpi.LastSportBetAt, microLayout),
+		"LastCasinoBetTimeLocal":  s.localPtr(kpi.LastCasinoBetAt, microLayout),
+	}, nil
+}
+
+func getClientRegistrations(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		Date time.Time `json:"DateLocal"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+
+	type registration struct {
+		ID        backoffice.PlayerID `json:"ClientId"`
+		CreatedAt string              `json:"CreatedLocal"`
+		Username  string              `json:"Login"`
+		FullName  string              `json:"Name"`
+	}
+	day := req.Date.In(s.Location).Format(dayLayout)
+	registrations := make([]registration, 0)
+	for _, p := range s.players {
+		if p.CreatedAt.In(s.Location).Format(dayLayout) != day {
+			continue
+		}
+		registrations = append(registrations, registration{
+			ID:        p.ID,
+			CreatedAt: dateTime(backoffice.DateTime(p.CreatedAt)),
+			Username:  p.Username,
+			FullName:  strings.TrimSpace(p.FirstName + " " + p.LastName),
+		})
+	}
+	return registrations, nil
+}
+
+func getClientRestriction(s *Server, r *http.Request, body []byte) (any, error) {
+	id, err := queryID(r.URL.Query().Get("clientId"))
+	if err != nil {
+		return nil, err
+	}
+	p := s.player(backoffice.PlayerID(id))
+	if p == nil {
+		return nil, errNotFound
+	}
+	return toWireRestriction(p.Restriction), nil
+}
+
+func saveClientRestriction(s *Server, r *http.Request, body []byte) (any, error) {
+	var req backoffice.SaveClientRestrictionRequest
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	p := s.player(req.PlayerID)
+	if p == nil {
+		return nil, errNotFound
+	}
+
+	updatedAt := backoffice.DateTime(time.Now())
+	p.Restriction = backoffice.GetClientRestrictionResult{
+		PlayerID:                req.PlayerID,
+		CanLogin:                req.CanLogin,
+		CanBet:                  req.CanBet,
+		CanDeposit:              req.CanDeposit,
+		CanWithdraw:             req.CanWithdraw,
+		CanIncreaseLimit:        req.CanIncreaseLimit,
+		CanClaimBonus:           req.CanClaimBonus,
+		CanCasinoLogin:          req.CanCasinoLogin,
+		CanUploadDocument:       req.CanUploadDocument,
+		IsWithdrawalAutoConfirm: p.Restriction.IsWithdrawalAutoConfirm,
+		UpdatedAt:               &updatedAt,
+	}
+	return nil, nil
+}
+
+func createClientPaymentDocument(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		PlayerID backoffice.PlayerID `json:"ClientId"`
+		Amount   string              `json:"Amount"`
+		Note     string              `json:"Info"`
+		Type     int                 `json:"DocTypeInt"`
+		Currency string              `json:"CurrencyId"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	p := s.player(req.PlayerID)
+	if p == nil {
+		return nil, errNotFound
+	}
+	amount, err := backoffice.ParseDecimal(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type != 3 {
+		return nil, fmt.Errorf("unsupported document type %d", req.Type)
+	}
+
+	note := req.Note
+	p.Balance += amount.Float64()
+	s.transactions = append(s.transactions, backoffice.Transaction{
+		ID:        s.id(),
+		Amount:    amount.Float64(),
+		Money:     backoffice.NewMoney(amount, req.Currency),
+		PlayerID:  req.PlayerID,
+		Type:      backoffice.TransactionTypeCorrectionUp,
+		Note:      &note,
+		CreatedAt: backoffice.DateTime(time.Now()),
+	})
+	return nil, nil
+}
+
+func getClientTransactions(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		FromDate        *string             `json:"StartTimeLocal"`
+		ToDate          *string             `json:"EndTimeLocal"`
+		PlayerID        backoffice.PlayerID `json:"ClientId"`
+		DocumentTypeIDs []int               `json:"DocumentTypeIds"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.FromDate, dayLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.ToDate, dayLayout)
+	if err != nil {
+		return nil, err
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	transactions := make([]wireTransaction, 0)
+	for _, t := range s.transactions {
+		if req.PlayerID != 0 && t.PlayerID != req.PlayerID {
+			continue
+		}
+		if !inRange(time.Time(t.CreatedAt), from, to) {
+			continue
+		}
+		wt := toWireTransaction(t)
+		if len(req.DocumentTypeIDs) > 0 && !slices.Contains(req.DocumentTypeIDs, wt.Type) {
+			continue
+		}
+		transactions = append(transactions, wt)
+	}
+	return map[string]any{"Objects": transactions}, nil
+}
+
+func getClientCasinoGames(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		PlayerID backoffice.PlayerID `json:"ClientId"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	games := s.casinoGames[req.PlayerID]
+	if games == nil {
+		games = []backoffice.PlayerCasinoGame{}
+	}
+	return games, nil
+}
+
+func getClientBonuses(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		PlayerID backoffice.PlayerID `json:"ClientId"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	bonuses := make([]wirePlayerBonus, 0)
+	for _, b := range s.bonuses[req.PlayerID] {
+		bonuses = append(bonuses, toWirePlayerBonus(b))
+	}
+	return bonuses, nil
+}
+
+func addClientToBonus(s *Server, r *http.Request, body []byte) (any, error) {
+	var req backoffice.AddBonusToPlayerRequest
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	if s.player(req.PlayerID) == nil {
+		return nil, errNotFound
+	}
+
+	s.bonuses[req.PlayerID] = append(s.bonuses[req.PlayerID], backoffice.PlayerBonus{
+		ID:        s.id(),
+		Amount:    req.Amount,
+		CreatedAt: time.Now().UTC(),
+		Result:    backoffice.BonusResultPending,
+		State:     backoffice.BonusStatePending,
+		BonusID:   req.BonusID,
+	})
+	return nil, nil
+}
+
+func cancelWageringBonus(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		BonusID int64 `json:"BonusId"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	for _, bonuses := range s.bonuses {
+		for i := range bonuses {
+			if bonuses[i].ID == req.BonusID {
+				bonuses[i].Result = backoffice.BonusResultCancelled
+				return nil, nil
+			}
+		}
+	}
+	return nil, errNotFound
+}
+
+func getWithdrawals(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		FromDate        *string                       `json:"FromDateLocal"`
+		ToDate          *string                       `json:"ToDateLocal"`
+		ID              *int64                        `json:"Id"`
+		PlayerID        *backoffice.PlayerID          `json:"ClientId"`
+		PaymentSystemID *int32                        `json:"PaymentSystemId"`
+		States          []backoffice.WithdrawalStatus `json:"StateList"`
+		MinAmount       *backoffice.Decimal           `json:"AmountFrom"`
+		MaxAmount       *backoffice.Decimal           `json:"AmountTo"`
+		MaxRows         int                           `json:"MaxRows"`
+		SkeepRows       int                           `json:"SkeepRows"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.FromDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.ToDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]wireWithdrawal, 0)
+	var total backoffice.Decimal
+	for _, w := range s.withdrawals {
+		amount := wireAmount(w.Amount, w.Money)
+		switch {
+		case req.ID != nil && w.ID != *req.ID,
+			req.PlayerID != nil && w.PlayerID != *req.PlayerID,
+			req.PaymentSystemID != nil && w.PaymentSystemID != *req.PaymentSystemID,
+			len(req.States) > 0 && !slices.Contains(req.States, w.Status),
+			req.MinAmount != nil && amount.Cmp(*req.MinAmount) < 0,
+			req.MaxAmount != nil && amount.Cmp(*req.MaxAmount) > 0,
+			!inRange(time.Time(w.RequestedAt), from, to):
+			continue
+		}
+		withdrawals = append(withdrawals, toWireWithdrawal(w))
+		if total, err = total.Add(amount); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{
+		"ClientRequests": page(withdrawals, req.SkeepRows, req.MaxRows),
+		"Totals": map[string]any{
+			"Count":  len(withdrawals),
+			"Amount": total,
+		},
+	}, nil
+}
+
+func getDeposits(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		FromDate  *string `json:"FromCreatedDateLocal"`
+		ToDate    *string `json:"ToCreatedDateLocal"`
+		MaxRows   int     `json:"MaxRows"`
+		SkeepRows int     `json:"SkeepRows"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.FromDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.ToDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	type deposit struct {
+		ID              int64               `json:"Id"`
+		Amount          backoffice.Decimal  `json:"Amount"`
+		PlayerID        backoffice.PlayerID `json:"ClientId"`
+		CreatedAt       string              `json:"CreatedLocal"`
+		PaymentMethod   string              `json:"PaymentSystemName"`
+		Currency        string              `json:"CurrencyId"`
+		PartnerID       int64               `json:"PartnerId"`
+		PaymentMethodID int32               `json:"PaymentSystemId"`
+	}
+	deposits := make([]deposit, 0)
+	for _, d := range s.deposits {
+		if !inRange(d.CreatedAt, from, to) {
+			continue
+		}
+		deposits = append(deposits, deposit{
+			ID:              d.ID,
+			Amount:          wireAmount(d.Amount, d.Money),
+			PlayerID:        d.PlayerID,
+			CreatedAt:       s.local(d.CreatedAt),
+			PaymentMethod:   d.PaymentMethod,
+			Currency:        d.Currency,
+			PartnerID:       d.PartnerID,
+			PaymentMethodID: d.PaymentMethodID,
+		})
+	}
+
+	return map[string]any{
+		"Documents": map[string]any{
+			"Objects": page(deposits, req.SkeepRows, req.MaxRows),
+			"Count":   len(deposits),
+		},
+	}, nil
+}
+
+func getDocuments(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		FromDate  *string `json:"FromCreatedDateLocal"`
+		ToDate    *string `json:"ToCreatedDateLocal"`
+		MaxRows   int     `json:"MaxRows"`
+		SkeepRows int     `json:"SkeepRows"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.FromDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.ToDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]wireTransaction, 0)
+	for _, t := range s.transactions {
+		if !inRange(time.Time(t.CreatedAt), from, to) {
+			continue
+		}
+		transactions = append(transactions, toWireTransaction(t))
+	}
+	return map[string]any{
+		"Objects": page(transactions, req.SkeepRows, req.MaxRows),
+		"Count":   len(transactions),
+	}, nil
+}
+
+func getBetHistory(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		FromDate *string                    `json:"StartDateLocal"`
+		ToDate   *string                    `json:"EndDateLocal"`
+		PlayerID *backoffice.PlayerID       `json:"ClientId"`
+		Status   *backoffice.SportBetStatus `json:"State"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	from, err := s.parseRequestTime(req.FromDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.parseRequestTime(req.ToDate, requestLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	bets := make([]wireSportBet, 0)
+	var totals backoffice.SportBetTotals
+	for _, b := range s.bets {
+		if req.PlayerID != nil && b.playerID != *req.PlayerID {
+			continue
+		}
+		if req.Status != nil && b.bet.Status != *req.Status {
+			continue
+		}
+		if !inRange(time.Time(b.bet.CreatedAt), from, to) {
+			continue
+		}
+		bets = append(bets, toWireSportBet(b.bet))
+		totals.EquivalentAmountSum += b.bet.Amount
+		if b.bet.Status == backoffice.SportBetStatusWon {
+			totals.EquivalentWinningSum += b.bet.Amount * b.bet.Price
+		}
+	}
+
+	return map[string]any{
+		"BetData":   map[string]any{"Objects": bets},
+		"BetTotals": totals,
+	}, nil
+}
+
+func getSportKindReport(s *Server, r *http.Request, body []byte) (any, error) {
+	return []backoffice.SportKindReport{}, nil
+}
+
+func getClientPromoCodes(s *Server, r *http.Request, body []byte) (any, error) {
+	return []any{}, nil
+}
+
+func paymentAPI(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		RequestType int     `json:"PaymentRequestType"`
+		Status      *int    `json:"status"`
+		Name        *string `json:"system_name"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+
+	switch req.RequestType {
+	case 1:
+		methods := make([]backoffice.PaymentMethod, 0)
+		for _, m := range s.paymentMethods {
+			if req.Name != nil && m.Name != *req.Name {
+				continue
+			}
+			if req.Status != nil && m.IsActive.Bool() != (*req.Status == 1) {
+				continue
+			}
+			methods = append(methods, m)
+		}
+		return methods, nil
+	case 6:
+		var m backoffice.PaymentMethod
+		if err := decodeBody(body, &m); err != nil {
+			return nil, err
+		}
+		for i := range s.paymentMethods {
+			if s.paymentMethods[i].ID == m.ID {
+				s.paymentMethods[i] = m
+				return nil, nil
+			}
+		}
+		return nil, errNotFound
+	default:
+		return nil, fmt.Errorf("unsupported payment request type %d", req.RequestType)
+	}
+}
+
+func getPartnerDomains(s *Server, r *http.Request, body []byte) (any, error) {
+	domains := s.domains
+	if domains == nil {
+		domains = []backoffice.PartnerDomain{}
+	}
+	return domains, nil
+}
+
+func setActiveDomain(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		ID int32 `json:"Id"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	for i := range s.domains {
+		if s.domains[i].ID == req.ID {
+			s.domains[i].Status = backoffice.PartnerDomainStatusActive
+			return nil, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func savePromoCode(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		Code      string `json:"Code"`
+		StartDate string `json:"StartDateLocal"`
+		EndDate   string `json:"EndDateLocal"`
+		MaxUses   string `json:"MaxCount"`
+		Type      int    `json:"TypeId"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	for _, p := range s.promoCodes {
+		if p.Code == req.Code {
+			return nil, fmt.Errorf("promo code %s already exists", req.Code)
+		}
+	}
+	startDate, err := time.Parse(requestLayout, req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := time.Parse(requestLayout, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	maxUses, err := strconv.Atoi(req.MaxUses)
+	if err != nil {
+		return nil, err
+	}
+
+	s.promoCodes = append(s.promoCodes, backoffice.PromoCode{
+		ID:        s.id(),
+		Code:      req.Code,
+		CreatedAt: time.Now().UTC(),
+		StartDate: startDate,
+		EndDate:   endDate,
+		MaxUses:   maxUses,
+		Type:      req.Type,
+	})
+	return nil, nil
+}
+
+func getPromoCodes(s *Server, r *http.Request, body []byte) (any, error) {
+	var req struct {
+		Code      string `json:"Code"`
+		MaxRows   int    `json:"MaxRows"`
+		SkeepRows int    `json:"SkeepRows"`
+	}
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+
+	type promoCode struct {
+		ID        int64  `json:"Id"`
+		Code      string `json:"Code"`
+		CreatedAt string `json:"Created"`
+		EndDate   string `json:"EndDateLocal"`
+		StartDate string `json:"StartDateLocal"`
+		MaxCount  int    `json:"MaxCount"`
+		UsedCount int    `json:"UsedCount"`
+		Type      int    `json:"TypeId"`
+	}
+	promoCodes := make([]promoCode, 0)
+	for _, p := range s.promoCodes {
+		if req.Code != "" && !strings.Contains(p.Code, req.Code) {
+			continue
+		}
+		promoCodes = append(promoCodes, promoCode{
+			ID:        p.ID,
+			Code:      p.Code,
+			CreatedAt: p.CreatedAt.UTC().Format("2006-01-02T15:04:05.99999Z07:00"),
+			EndDate:   p.EndDate.Format(requestLayout),
+			StartDate: p.StartDate.Format(requestLayout),
+			MaxCount:  p.MaxUses,
+			UsedCount: p.UsedCount,
+			Type:      p.Type,
+		})
+	}
+	return map[string]any{
+		"Count":   len(promoCodes),
+		"Objects": page(promoCodes, req.SkeepRows, req.MaxRows),
+	}, nil
+}
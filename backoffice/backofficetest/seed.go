@@ -0,0 +1,127 @@
+package backofficetest
+
+import (
+	"slices"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+type sportBet struct {
+	playerID backoffice.PlayerID
+	bet      backoffice.SportBet
+}
+
+// AddPlayer seeds a player. The KPI and restriction player IDs default to the
+// player's ID.
+func (s *Server) AddPlayer(p Player) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p.KPI.PlayerID == 0 {
+		p.KPI.PlayerID = p.ID
+	}
+	if p.Restriction.PlayerID == 0 {
+		p.Restriction.PlayerID = p.ID
+	}
+	s.players = append(s.players, &p)
+}
+
+// Player returns the current state of a seeded player.
+func (s *Server) Player(id backoffice.PlayerID) (Player, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.player(id)
+	if p == nil {
+		return Player{}, false
+	}
+	return *p, true
+}
+
+func (s *Server) AddDeposit(d backoffice.Deposit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deposits = append(s.deposits, d)
+}
+
+func (s *Server) AddTransaction(t backoffice.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions = append(s.transactions, t)
+}
+
+// Transactions returns every transaction, including the ones created through
+// AddPaymentToPlayer.
+func (s *Server) Transactions() []backoffice.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.transactions)
+}
+
+func (s *Server) AddWithdrawal(w backoffice.Withdrawal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.withdrawals = append(s.withdrawals, w)
+}
+
+func (s *Server) AddSportBet(playerID backoffice.PlayerID, b backoffice.SportBet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bets = append(s.bets, sportBet{playerID: playerID, bet: b})
+}
+
+func (s *Server) AddPlayerBonus(playerID backoffice.PlayerID, b backoffice.PlayerBonus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bonuses[playerID] = append(s.bonuses[playerID], b)
+}
+
+// PlayerBonuses returns the bonuses of a player, including the ones added
+// through AddBonusToPlayer.
+func (s *Server) PlayerBonuses(playerID backoffice.PlayerID) []backoffice.PlayerBonus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.bonuses[playerID])
+}
+
+func (s *Server) SetPlayerCasinoGames(playerID backoffice.PlayerID, games []backoffice.PlayerCasinoGame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.casinoGames[playerID] = slices.Clone(games)
+}
+
+func (s *Server) AddPaymentMethod(m backoffice.PaymentMethod) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paymentMethods = append(s.paymentMethods, m)
+}
+
+func (s *Server) AddPartnerDomain(d backoffice.PartnerDomain) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains = append(s.domains, d)
+}
+
+func (s *Server) AddPromoCode(p backoffice.PromoCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.promoCodes = append(s.promoCodes, p)
+}
+
+// PromoCodes returns every promo code, including the ones created through
+// CreatePromoCode.
+func (s *Server) PromoCodes() []backoffice.PromoCode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.promoCodes)
+}
+
+// player looks up a player. The caller must hold s.mu.
+func (s *Server) player(id backoffice.PlayerID) *Player {
+	for _, p := range s.players {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+// Package backofficetest provides an in-process fake of the BetConstruct
+// backoffice API for end-to-end tests of code built on backoffice.Client.
+//
+// The fake serves the same paths and {Data, HasError, AlertMessage} envelope
+// as the real backoffice, keeps its state in memory and can be told to fail
+// requests or reject tokens:
+//
+//	srv := backofficetest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddPlayer(backofficetest.Player{Player: backoffice.Player{ID: 42, Username: "john"}})
+//	c, err := srv.Client()
+package backofficetest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+// DefaultToken is the token Client authenticates with.
+const DefaultToken = "backofficetest-token"
+
+// Fault describes an injected failure. A zero StatusCode with an AlertMessage
+// answers 200 with HasError set, like the backoffice does for business errors.
+type Fault struct {
+	StatusCode   int
+	AlertMessage string
+	Header       http.Header
+	Body         string
+	// Count is the number of requests the fault applies to. Zero means every
+	// request until ClearFaults is called.
+	Count int
+}
+
+// Request is a request the server received, for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Token  string
+	Body   []byte
+}
+
+type handlerFunc func(s *Server, r *http.Request, body []byte) (any, error)
+
+type Server struct {
+	*httptest.Server
+
+	// Location is the backoffice time zone used for the *Local fields. It must
+	// match the client's WithTimeLocation and defaults to UTC.
+	Location *time.Location
+
+	mu             sync.Mutex
+	nextID         int64
+	players        []*Player
+	deposits       []backoffice.Deposit
+	transactions   []backoffice.Transaction
+	withdrawals    []backoffice.Withdrawal
+	bets           []sportBet
+	bonuses        map[backoffice.PlayerID][]backoffice.PlayerBonus
+	casinoGames    map[backoffice.PlayerID][]backoffice.PlayerCasinoGame
+	paymentMethods []backoffice.PaymentMethod
+	domains        []backoffice.PartnerDomain
+	promoCodes     []backoffice.PromoCode
+	faults         map[string]*Fault
+	rejected       map[string]int
+	requests       []Request
+}
+
+// Player is a seeded player together with the data the backoffice keeps
+// about it outside of backoffice.Player.
+type Player struct {
+	backoffice.Player
+	CreatedAt      time.Time
+	Balance        float64
+	PlayerCategory *backoffice.PlayerCategory
+	FirstDepositAt time.Time
+	KPI            backoffice.PlayerKPI
+	Restriction    backoffice.GetClientRestrictionResult
+}
+
+// NewServer starts a fake backoffice. The caller must call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		Location:    time.UTC,
+		nextID:      1000,
+		bonuses:     make(map[backoffice.PlayerID][]backoffice.PlayerBonus),
+		casinoGames: make(map[backoffice.PlayerID][]backoffice.PlayerCasinoGame),
+		faults:      make(map[string]*Fault),
+		rejected:    make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a backoffice.Client pointed at the server and authenticated
+// with DefaultToken. Options are applied after the defaults.
+func (s *Server) Client(opts ...backoffice.Option) (backoffice.Client, error) {
+	return backoffice.New(append([]backoffice.Option{
+		backoffice.WithBaseURL(s.URL),
+		backoffice.WithHTTPClient(s.Server.Client()),
+		backoffice.WithAuthToken(DefaultToken),
+		backoffice.WithTimeLocation(s.Location),
+	}, opts...)...)
+}
+
+// InjectFault makes requests to path, such as "/Client/GetClients", fail.
+func (s *Server) InjectFault(path string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[path] = &f
+}
+
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// RejectToken answers every request made with token with statusCode, which
+// is normally 401, 403 or 429.
+func (s *Server) RejectToken(token string, statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejected[token] = statusCode
+}
+
+func (s *Server) AcceptToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rejected, token)
+}
+
+// Requests returns every request received so far, including rejected ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	path, ok := apiPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	token := r.Header.Get("Authentication")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.RawQuery,
+		Token:  token,
+		Body:   body,
+	})
+	if status, ok := s.rejected[token]; ok {
+		s.mu.Unlock()
+		w.WriteHeader(status)
+		return
+	}
+	fault := s.takeFault(path)
+	s.mu.Unlock()
+
+	if fault != nil {
+		writeFault(w, fault)
+		return
+	}
+
+	h, ok := routes[r.Method+" "+path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	data, err := h(s, r, body)
+	s.mu.Unlock()
+	if err != nil {
+		writeEnvelope(w, nil, err.Error())
+		return
+	}
+	writeEnvelope(w, data, "")
+}
+
+// takeFault returns the fault for path and consumes one use of it. The caller
+// must hold s.mu.
+func (s *Server) takeFault(path string) *Fault {
+	f, ok := s.faults[path]
+	if !ok {
+		return nil
+	}
+	if f.Count > 0 {
+		f.Count--
+		if f.Count == 0 {
+			delete(s.faults, path)
+		}
+	}
+	return f
+}
+
+// apiPath strips the /api/{language} prefix.
+func apiPath(p string) (string, bool) {
+	rest, ok := strings.CutPrefix(p, "/api/")
+	if !ok {
+		return "", false
+	}
+	_, path, ok := strings.Cut(rest, "/")
+	if !ok {
+		return "", false
+	}
+	return "/" + path, true
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	for k, v := range f.Header {
+		w.Header()[k] = v
+	}
+	if f.StatusCode == 0 {
+		writeEnvelope(w, nil, f.AlertMessage)
+		return
+	}
+	w.WriteHeader(f.StatusCode)
+	io.WriteString(w, f.Body)
+}
+
+func writeEnvelope(w http.ResponseWriter, data any, alertMessage string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Data         any    `json:"Data"`
+		HasError     bool   `json:"HasError"`
+		AlertMessage string `json:"AlertMessage"`
+	}{
+		Data:         data,
+		HasError:     alertMessage != "",
+		AlertMessage: alertMessage,
+	})
+}
+
+// id returns a fresh identifier. The caller must hold s.mu.
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
@@ -0,0 +1,272 @@
+package backofficetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+	"github.com/extrasoftorg/betconstruct/backoffice/backofficetest"
+)
+
+func newServer(t *testing.T) (*backofficetest.Server, backoffice.Client) {
+	t.Helper()
+
+	srv := backofficetest.NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.Client()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return srv, c
+}
+
+func TestServer_Players(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	testUser := backoffice.PlayerCategory(10)
+	srv.AddPlayer(backofficetest.Player{
+		Player:         backoffice.Player{ID: 42, Username: "john", FirstName: "John"},
+		CreatedAt:      createdAt,
+		Balance:        10,
+		PlayerCategory: &testUser,
+	})
+	srv.AddPlayer(backofficetest.Player{
+		Player:    backoffice.Player{ID: 43, Username: "jane"},
+		CreatedAt: createdAt.AddDate(0, 1, 0),
+	})
+
+	player, err := c.GetPlayer(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	if player.Username != "john" || player.FirstName != "John" {
+		t.Fatalf("got player %+v, want john", player)
+	}
+
+	players, err := c.ListPlayers(ctx, backoffice.ListPlayersRequest{ToRegistrationDate: createdAt.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to list players: %v", err)
+	}
+	if len(players) != 1 || players[0].ID != 42 || !players[0].CreatedAt.Equal(createdAt) {
+		t.Fatalf("got players %+v, want only john registered at %v", players, createdAt)
+	}
+	if pc := players[0].PlayerCategory; pc == nil || !pc.Is(backoffice.PlayerCategoryTestUser) {
+		t.Fatalf("got category %v, want the test user category", pc)
+	}
+
+	if _, err := c.GetPlayer(ctx, 1); err == nil {
+		t.Fatalf("got no error for an unknown player")
+	}
+}
+
+func TestServer_Deposits(t *testing.T) {
+	srv, c := newServer(t)
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		srv.AddDeposit(backoffice.Deposit{
+			ID:        int64(i + 1),
+			Amount:    100,
+			PlayerID:  42,
+			CreatedAt: from.Add(time.Duration(i) * time.Hour),
+			Currency:  "TRY",
+		})
+	}
+
+	out, err := c.ListDeposits(context.Background(), backoffice.ListDepositsInput{FromDate: from, Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("failed to list deposits: %v", err)
+	}
+	if out.Count != 5 || len(out.Deposits) != 2 || out.Deposits[0].ID != 3 {
+		t.Fatalf("got %d of %d deposits starting at %d, want 2 of 5 starting at 3", len(out.Deposits), out.Count, out.Deposits[0].ID)
+	}
+}
+
+func TestServer_Mutations(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+	srv.AddPlayer(backofficetest.Player{Player: backoffice.Player{ID: 42}})
+
+	err := c.AddPaymentToPlayer(ctx, backoffice.AddPaymentToPlayerRequest{
+		PlayerID: 42,
+		Amount:   25.5,
+		Type:     backoffice.AddPaymentToPlayerRequestTypeCorrectionUp,
+		Currency: "TRY",
+	})
+	if err != nil {
+		t.Fatalf("failed to add payment: %v", err)
+	}
+	if p, _ := srv.Player(42); p.Balance != 25.5 {
+		t.Fatalf("got balance %v, want 25.5", p.Balance)
+	}
+
+	money := backoffice.NewMoney(backoffice.NewDecimal(1001, 2), "TRY")
+	err = c.AddPaymentToPlayer(ctx, backoffice.AddPaymentToPlayerRequest{
+		PlayerID: 42,
+		Type:     backoffice.AddPaymentToPlayerRequestTypeCorrectionUp,
+		Money:    &money,
+	})
+	if err != nil {
+		t.Fatalf("failed to add payment: %v", err)
+	}
+	transactions := srv.Transactions()
+	if got := transactions[len(transactions)-1].Money; got != money {
+		t.Fatalf("got transaction money %s, want %s", got, money)
+	}
+
+	if err := c.AddBonusToPlayer(ctx, backoffice.AddBonusToPlayerRequest{PlayerID: 42, BonusID: 7, Amount: 50}); err != nil {
+		t.Fatalf("failed to add bonus: %v", err)
+	}
+	bonuses, err := c.ListPlayerBonuses(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to list bonuses: %v", err)
+	}
+	if len(bonuses) != 1 || bonuses[0].BonusID != 7 || bonuses[0].Result != backoffice.BonusResultPending {
+		t.Fatalf("got bonuses %+v, want one pending bonus 7", bonuses)
+	}
+
+	if err := c.CancelPlayerBonus(ctx, backoffice.CancelPlayerBonusRequest{BonusID: bonuses[0].ID}); err != nil {
+		t.Fatalf("failed to cancel bonus: %v", err)
+	}
+	if got := srv.PlayerBonuses(42)[0].Result; got != backoffice.BonusResultCancelled {
+		t.Fatalf("got bonus result %s, want cancelled", got)
+	}
+
+	if err := c.SaveClientRestriction(ctx, backoffice.SaveClientRestrictionRequest{PlayerID: 42, CanLogin: true}); err != nil {
+		t.Fatalf("failed to save restriction: %v", err)
+	}
+	restriction, err := c.GetClientRestriction(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to get restriction: %v", err)
+	}
+	if !restriction.CanLogin || restriction.CanBet || restriction.UpdatedAt == nil {
+		t.Fatalf("got restriction %+v, want only CanLogin", restriction)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+	srv.AddPlayer(backofficetest.Player{Player: backoffice.Player{ID: 42}})
+
+	srv.InjectFault("/Client/GetClientById", backofficetest.Fault{StatusCode: http.StatusServiceUnavailable, Count: 1})
+	if _, err := c.GetPlayer(ctx, 42); !errors.Is(err, backoffice.ErrServiceUnavailable) {
+		t.Fatalf("got error %v, want %v", err, backoffice.ErrServiceUnavailable)
+	}
+	if _, err := c.GetPlayer(ctx, 42); err != nil {
+		t.Fatalf("fault outlived its count: %v", err)
+	}
+
+	srv.InjectFault("/Client/GetClientById", backofficetest.Fault{AlertMessage: "Client is blocked"})
+	_, err := c.GetPlayer(ctx, 42)
+	var apiErr *backoffice.APIError
+	if !errors.As(err, &apiErr) || !apiErr.HasError || apiErr.AlertMessage != "Client is blocked" {
+		t.Fatalf("got error %v, want business error", err)
+	}
+	srv.ClearFaults()
+
+	srv.RejectToken(backofficetest.DefaultToken, http.StatusTooManyRequests)
+	if _, err := c.GetPlayer(ctx, 42); !errors.Is(err, backoffice.ErrTooManyRequests) {
+		t.Fatalf("got error %v, want %v", err, backoffice.ErrTooManyRequests)
+	}
+	srv.AcceptToken(backofficetest.DefaultToken)
+
+	if got := len(srv.Requests()); got != 4 {
+		t.Fatalf("server saw %d requests, want 4", got)
+	}
+}
+
+func TestServer_Withdrawals(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+
+	requestedAt := backoffice.DateTime(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 1, Amount: 100, PlayerID: 42, RequestedAt: requestedAt, Status: backoffice.WithdrawalStatusPending})
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 2, Amount: 50, PlayerID: 42, RequestedAt: requestedAt, Status: backoffice.WithdrawalStatusPending})
+
+	out, err := c.ListWithdrawalsWithTotals(ctx, backoffice.ListWithdrawalsRequest{})
+	if err != nil {
+		t.Fatalf("failed to list withdrawals: %v", err)
+	}
+	if len(out.Withdrawals) != 2 || out.Totals.Count != 2 || !out.Totals.Money.Amount.Equal(backoffice.NewDecimal(150, 0)) {
+		t.Fatalf("got %d withdrawals and totals %+v, want 2 totalling 150", len(out.Withdrawals), out.Totals)
+	}
+}
+
+func TestServer_WithdrawalFilters(t *testing.T) {
+	srv, c := newServer(t)
+	ctx := context.Background()
+
+	day := func(d int) backoffice.DateTime {
+		return backoffice.DateTime(time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC))
+	}
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 1, Amount: 500, PlayerID: 42, PaymentSystemID: 7, RequestedAt: day(1), Status: backoffice.WithdrawalStatusPending})
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 2, Amount: 50, PlayerID: 42, PaymentSystemID: 7, RequestedAt: day(2), Status: backoffice.WithdrawalStatusPending})
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 3, Amount: 900, PlayerID: 43, PaymentSystemID: 8, RequestedAt: day(3), Status: backoffice.WithdrawalStatusPending})
+	srv.AddWithdrawal(backoffice.Withdrawal{ID: 4, Amount: 700, PlayerID: 42, PaymentSystemID: 7, RequestedAt: day(4), Status: backoffice.WithdrawalStatusPaid})
+
+	tests := []struct {
+		name string
+		req  backoffice.ListWithdrawalsRequest
+		want []int64
+	}{
+		{"status", backoffice.ListWithdrawalsRequest{Statuses: []backoffice.WithdrawalStatus{backoffice.WithdrawalStatusPaid}}, []int64{4}},
+		{"player", backoffice.ListWithdrawalsRequest{PlayerID: 43}, []int64{3}},
+		{"payment system", backoffice.ListWithdrawalsRequest{PaymentSystemID: 7}, []int64{1, 2, 4}},
+		{"amount range", backoffice.ListWithdrawalsRequest{MinAmount: 100, MaxAmount: 800}, []int64{1, 4}},
+		{"exact amount range", backoffice.ListWithdrawalsRequest{
+			MinMoney: backoffice.NewDecimal(100, 0),
+			MaxMoney: backoffice.NewDecimal(800, 0),
+		}, []int64{1, 4}},
+		{"date range", backoffice.ListWithdrawalsRequest{FromDate: time.Time(day(2)), ToDate: time.Time(day(3))}, []int64{2, 3}},
+		{"pending above amount", backoffice.ListWithdrawalsRequest{
+			Statuses:  []backoffice.WithdrawalStatus{backoffice.WithdrawalStatusPending},
+			MinAmount: 100,
+		}, []int64{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withdrawals, err := c.ListWithdrawals(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("failed to list withdrawals: %v", err)
+			}
+			var got []int64
+			for _, w := range withdrawals {
+				got = append(got, w.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got withdrawals %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	out, err := c.ListWithdrawalsWithTotals(ctx, backoffice.ListWithdrawalsRequest{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("failed to list withdrawals: %v", err)
+	}
+	if len(out.Withdrawals) != 1 || out.Withdrawals[0].ID != 2 || out.Totals.Count != 4 {
+		t.Fatalf("got %+v, want withdrawal 2 out of 4", out)
+	}
+
+	// The sequence is ranged twice to check that it does not keep state.
+	all := backoffice.AllWithdrawals(ctx, c, backoffice.ListWithdrawalsRequest{Limit: 3})
+	for range 2 {
+		var ids []int64
+		for w, err := range all {
+			if err != nil {
+				t.Fatalf("failed to iterate withdrawals: %v", err)
+			}
+			ids = append(ids, w.ID)
+		}
+		if !slices.Equal(ids, []int64{1, 2, 3, 4}) {
+			t.Fatalf("got withdrawals %v, want all four", ids)
+		}
+	}
+}
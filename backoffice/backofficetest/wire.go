@@ -0,0 +1,257 @@
+package backofficetest
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/backoffice"
+)
+
+const (
+	localLayout   = "2006-01-02T15:04:05.999"
+	microLayout   = "2006-01-02T15:04:05.999999"
+	requestLayout = "02-01-06 - 15:04:05"
+	dayLayout     = "02-01-06"
+)
+
+var errNotFound = errors.New("Object not found")
+
+// local formats t like the backoffice's *Local fields.
+func (s *Server) local(t time.Time) string {
+	return t.In(s.Location).Format(localLayout)
+}
+
+func (s *Server) localPtr(t time.Time, layout string) *string {
+	if t.IsZero() {
+		return nil
+	}
+	v := t.In(s.Location).Format(layout)
+	return &v
+}
+
+// parseRequestTime parses the "02-01-06 - 15:04:05" layout the client sends.
+// A nil or empty value parses as the zero time.
+func (s *Server) parseRequestTime(v *string, layout string) (time.Time, error) {
+	if v == nil || *v == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(layout, *v, s.Location)
+}
+
+// dateTime formats t like the fields the client decodes into
+// backoffice.DateTime, which are always in backoffice.TimeZone.
+func dateTime(t backoffice.DateTime) string {
+	return time.Time(t).In(backoffice.TimeZone).Format(localLayout)
+}
+
+// playerCategoryID is the wire form of a category, where 0 means none.
+func playerCategoryID(c *backoffice.PlayerCategory) int {
+	if c == nil {
+		return 0
+	}
+	return c.Int()
+}
+
+func dateTimePtr(t *backoffice.DateTime) *string {
+	if t == nil {
+		return nil
+	}
+	v := dateTime(*t)
+	return &v
+}
+
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+func page[T any](items []T, skip, limit int) []T {
+	if skip >= len(items) {
+		return []T{}
+	}
+	items = items[skip:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// wireAmount renders a seeded amount, preferring the exact Money value when
+// the seed sets one.
+func wireAmount(f float64, m backoffice.Money) backoffice.Decimal {
+	if !m.Amount.IsZero() {
+		return m.Amount
+	}
+	d, _ := backoffice.DecimalFromFloat(f)
+	return d
+}
+
+func queryID(v string) (int64, error) {
+	return strconv.ParseInt(v, 10, 64)
+}
+
+var transactionTypeIDs = map[backoffice.TransactionType]int{
+	backoffice.TransactionTypeWinning:        15,
+	backoffice.TransactionTypeBet:            10,
+	backoffice.TransactionTypeCorrectionUp:   301,
+	backoffice.TransactionTypeCorrectionDown: 302,
+	backoffice.TransactionTypeDeposit:        3,
+}
+
+var withdrawalStatusIDs = map[backoffice.WithdrawalStatus]int{
+	backoffice.WithdrawalStatusPending:   0,
+	backoffice.WithdrawalStatusPaid:      3,
+	backoffice.WithdrawalStatusRejected:  -2,
+	backoffice.WithdrawalStatusCancelled: -1,
+}
+
+var bonusResultIDs = map[backoffice.BonusResult]int{
+	backoffice.BonusResultPending:   0,
+	backoffice.BonusResultActivated: 1,
+	backoffice.BonusResultCancelled: 3,
+	backoffice.BonusResultExpired:   4,
+}
+
+var bonusStateIDs = map[backoffice.BonusState]int{
+	backoffice.BonusStatePending:   0,
+	backoffice.BonusStateActivated: 2,
+}
+
+var bonusTypeIDs = map[backoffice.BonusType]int{
+	backoffice.BonusTypeFreespin: 5,
+	backoffice.BonusTypeFreebet:  6,
+}
+
+type wireTransaction struct {
+	ID        int64               `json:"Id"`
+	Amount    backoffice.Decimal  `json:"Amount"`
+	Currency  string              `json:"CurrencyId,omitempty"`
+	PlayerID  backoffice.PlayerID `json:"ClientId"`
+	Type      int                 `json:"TypeId"`
+	Note      *string             `json:"Note"`
+	CreatedAt string              `json:"CreatedLocal"`
+}
+
+func toWireTransaction(t backoffice.Transaction) wireTransaction {
+	return wireTransaction{
+		ID:        t.ID,
+		Amount:    wireAmount(t.Amount, t.Money),
+		Currency:  t.Money.Currency,
+		PlayerID:  t.PlayerID,
+		Type:      transactionTypeIDs[t.Type],
+		Note:      t.Note,
+		CreatedAt: dateTime(t.CreatedAt),
+	}
+}
+
+type wireWithdrawal struct {
+	ID              int64               `json:"Id"`
+	Amount          backoffice.Decimal  `json:"Amount"`
+	Currency        string              `json:"CurrencyId,omitempty"`
+	PlayerID        backoffice.PlayerID `json:"ClientId"`
+	RequestedAt     string              `json:"RequestTimeLocal"`
+	PaymentMethod   string              `json:"PaymentSystemName"`
+	PaymentSystemID int32               `json:"PaymentSystemId"`
+	AllowedAt       *string             `json:"AllowTimeLocal"`
+	Info            string              `json:"Info"`
+	Status          int                 `json:"State"`
+}
+
+func toWireWithdrawal(w backoffice.Withdrawal) wireWithdrawal {
+	return wireWithdrawal{
+		ID:              w.ID,
+		Amount:          wireAmount(w.Amount, w.Money),
+		Currency:        w.Money.Currency,
+		PlayerID:        w.PlayerID,
+		RequestedAt:     dateTime(w.RequestedAt),
+		PaymentMethod:   w.PaymentMethod,
+		PaymentSystemID: w.PaymentSystemID,
+		AllowedAt:       dateTimePtr(w.AllowedAt),
+		Info:            w.Info,
+		Status:          withdrawalStatusIDs[w.Status],
+	}
+}
+
+type wireSportBet struct {
+	ID        int64                     `json:"Id"`
+	TypeName  string                    `json:"TypeName"`
+	Amount    float64                   `json:"Amount"`
+	Price     float64                   `json:"Price"`
+	CreatedAt string                    `json:"CreatedLocal"`
+	CalcDate  *string                   `json:"CalcDateLocal"`
+	Status    backoffice.SportBetStatus `json:"State"`
+}
+
+func toWireSportBet(b backoffice.SportBet) wireSportBet {
+	return wireSportBet{
+		ID:        b.ID,
+		TypeName:  b.TypeName,
+		Amount:    b.Amount,
+		Price:     b.Price,
+		CreatedAt: dateTime(b.CreatedAt),
+		CalcDate:  dateTimePtr(b.CalcDate),
+		Status:    b.Status,
+	}
+}
+
+type wirePlayerBonus struct {
+	ID             int64              `json:"Id"`
+	Amount         backoffice.Decimal `json:"Amount"`
+	Name           string             `json:"Name"`
+	CreatedAt      string             `json:"CreatedLocal"`
+	ResultType     int                `json:"ResultType"`
+	AcceptanceType int                `json:"AcceptanceType"`
+	BonusType      int                `json:"BonusType"`
+	BonusID        int64              `json:"PartnerBonusId"`
+}
+
+func toWirePlayerBonus(b backoffice.PlayerBonus) wirePlayerBonus {
+	return wirePlayerBonus{
+		ID:             b.ID,
+		Amount:         wireAmount(b.Amount, b.Money),
+		Name:           b.Name,
+		CreatedAt:      b.CreatedAt.UTC().Format("2006-01-02T15:04:05.999999999"),
+		ResultType:     bonusResultIDs[b.Result],
+		AcceptanceType: bonusStateIDs[b.State],
+		BonusType:      bonusTypeIDs[b.Type],
+		BonusID:        b.BonusID,
+	}
+}
+
+type wireRestriction struct {
+	PlayerID                backoffice.PlayerID `json:"ClientId"`
+	CanLogin                bool                `json:"CanLogin"`
+	CanBet                  bool                `json:"CanBet"`
+	CanDeposit              bool                `json:"CanDeposit"`
+	CanWithdraw             bool                `json:"CanWithdraw"`
+	CanIncreaseLimit        bool                `json:"CanIncreaseLimit"`
+	CanClaimBonus           bool                `json:"CanClaimBonus"`
+	CanCasinoLogin          bool                `json:"CanCasinoLogin"`
+	CanUploadDocument       bool                `json:"CanUploadDocument"`
+	IsWithdrawalAutoConfirm bool                `json:"IsWithdrawalAutoConfirm"`
+	UpdatedAt               *string             `json:"ModifedLocal"`
+}
+
+func toWireRestriction(r backoffice.GetClientRestrictionResult) wireRestriction {
+	return wireRestriction{
+		PlayerID:                r.PlayerID,
+		CanLogin:                r.CanLogin,
+		CanBet:                  r.CanBet,
+		CanDeposit:              r.CanDeposit,
+		CanWithdraw:             r.CanWithdraw,
+		CanIncreaseLimit:        r.CanIncreaseLimit,
+		CanClaimBonus:           r.CanClaimBonus,
+		CanCasinoLogin:          r.CanCasinoLogin,
+		CanUploadDocument:       r.CanUploadDocument,
+		IsWithdrawalAutoConfirm: r.IsWithdrawalAutoConfirm,
+		UpdatedAt:               dateTimePtr(r.UpdatedAt),
+	}
+}
+
+func decodeBody(body []byte, v any) error {
+	if len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, v)
+}
@@ -19,8 +19,9 @@ func (c *client) AddBonusToPlayer(ctx context.Context, req AddBonusToPlayerReque
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](
+	_, err = makeMutatingRequest[any](
 		ctx,
+		"AddBonusToPlayer",
 		http.MethodPost,
 		"/Client/AddClientToBonus",
 		body,
@@ -56,8 +57,12 @@ const (
 )
 
 type PlayerBonus struct {
-	ID        int64
-	Amount    float64
+	ID int64
+	// Deprecated: Amount loses precision; use Money.
+	Amount float64
+	// Money is the exact bonus amount, or the number of free spins or bets
+	// when the backoffice reports no amount. Its currency is not reported.
+	Money     Money
 	Name      string
 	CreatedAt time.Time
 	Result    BonusResult
@@ -69,7 +74,7 @@ type PlayerBonus struct {
 func (b *PlayerBonus) UnmarshalJSON(data []byte) error {
 	type wire struct {
 		ID             int64   `json:"Id"`
-		Amount         float64 `json:"Amount"`
+		Amount         Decimal `json:"Amount"`
 		Name           string  `json:"Name"`
 		CreatedAt      string  `json:"CreatedLocal"`
 		Count          int     `json:"Count"`
@@ -85,7 +90,7 @@ func (b *PlayerBonus) UnmarshalJSON(data []byte) error {
 
 	b.ID = w.ID
 	b.Name = w.Name
-	b.Amount = w.Amount
+	b.Money = NewMoney(w.Amount, "")
 
 	createdAt, err := time.Parse("2006-01-02T15:04:05.999999999", w.CreatedAt)
 	if err != nil {
@@ -93,9 +98,10 @@ func (b *PlayerBonus) UnmarshalJSON(data []byte) error {
 	}
 	b.CreatedAt = createdAt
 
-	if b.Amount <= 0 {
-		b.Amount = float64(w.Count)
+	if w.Amount.Sign() <= 0 {
+		b.Money = NewMoney(NewDecimal(int64(w.Count), 0), "")
 	}
+	b.Amount = b.Money.Amount.Float64()
 
 	switch w.ResultType {
 	// none
@@ -153,8 +159,9 @@ func (c *client) CancelPlayerBonus(ctx context.Context, req CancelPlayerBonusReq
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](
+	_, err = makeMutatingRequest[any](
 		ctx,
+		"CancelPlayerBonus",
 		http.MethodPost,
 		"/Client/CancelWageringBonusAsync",
 		body,
@@ -180,6 +187,7 @@ func (c *client) ListPlayerBonuses(ctx context.Context, playerID PlayerID) ([]Pl
 	}
 	bonuses, err := makeRequest[[]PlayerBonus](
 		ctx,
+		"ListPlayerBonuses",
 		http.MethodPost,
 		"/Client/GetClientBonuses",
 		body,
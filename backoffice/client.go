@@ -3,18 +3,34 @@ package backoffice
 import (
 	"net/http"
 	"time"
+
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
+	"github.com/extrasoftorg/betconstruct/internal/ratelimit"
+)
+
+const (
+	defaultBaseURL  = "https://backofficewebadmin.betconstruct.com"
+	defaultLanguage = "en"
 )
 
 type client struct {
 	httpClient   *http.Client
+	baseURL      string
+	language     string
+	endpoint     string
 	ts           TokenSource
 	maxAttempts  int
 	timeLocation *time.Location
+	retryPolicy  RetryPolicy
+	limiter      *ratelimit.Limiter
+	middleware   []Middleware
 }
 
 func New(opts ...Option) (Client, error) {
 	c := &client{
 		httpClient:   &http.Client{},
+		baseURL:      defaultBaseURL,
+		language:     defaultLanguage,
 		maxAttempts:  3,
 		timeLocation: time.UTC,
 	}
@@ -26,6 +42,12 @@ func New(opts ...Option) (Client, error) {
 		return nil, ErrMissingTokenSource
 	}
 
+	endpoint, err := endpoint.Join(c.baseURL, c.language)
+	if err != nil {
+		return nil, err
+	}
+	c.endpoint = endpoint
+
 	return c, nil
 }
 
@@ -37,6 +59,22 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithBaseURL points the client at another backoffice, such as a staging
+// environment or a local test server. The language segment is appended to it,
+// so it must not end in /api/en.
+func WithBaseURL(baseURL string) Option {
+	return func(c *client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithLanguage selects the language segment of the API path. It defaults to en.
+func WithLanguage(language string) Option {
+	return func(c *client) {
+		c.language = language
+	}
+}
+
 func WithAuthToken(authToken string) Option {
 	return func(c *client) {
 		c.ts = &staticTokenSource{token: authToken}
@@ -63,3 +101,9 @@ func WithTimeLocation(location *time.Location) Option {
 		c.timeLocation = location
 	}
 }
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
@@ -53,7 +53,8 @@ type ListDepositsOutput struct {
 }
 
 type Deposit struct {
-	ID              int64
+	ID int64
+	// Deprecated: Amount loses precision; use Money.
 	Amount          float64
 	PlayerID        PlayerID
 	CreatedAt       time.Time
@@ -61,6 +62,8 @@ type Deposit struct {
 	Currency        string
 	PartnerID       int64
 	PaymentMethodID int32
+	// Money is the exact amount in Currency.
+	Money Money
 }
 
 func (c *client) ListDeposits(ctx context.Context, in ListDepositsInput) (*ListDepositsOutput, error) {
@@ -71,7 +74,7 @@ func (c *client) ListDeposits(ctx context.Context, in ListDepositsInput) (*ListD
 
 	type responseDeposit struct {
 		ID              int64   `json:"Id"`
-		Amount          float64 `json:"Amount"`
+		Amount          Decimal `json:"Amount"`
 		PlayerID        int64   `json:"ClientId"`
 		CreatedAt       string  `json:"CreatedLocal"`
 		PaymentMethod   string  `json:"PaymentSystemName"`
@@ -87,6 +90,7 @@ func (c *client) ListDeposits(ctx context.Context, in ListDepositsInput) (*ListD
 	}
 	resp, err := makeRequest[response](
 		ctx,
+		"ListDeposits",
 		http.MethodPost,
 		"/Financial/GetDepositsWithdrawalsWithPaging",
 		body,
@@ -105,13 +109,14 @@ func (c *client) ListDeposits(ctx context.Context, in ListDepositsInput) (*ListD
 
 		deposit := Deposit{
 			ID:              d.ID,
-			Amount:          d.Amount,
+			Amount:          d.Amount.Float64(),
 			PlayerID:        PlayerID(d.PlayerID),
 			PaymentMethod:   d.PaymentMethod,
 			CreatedAt:       createdAt.UTC(),
 			Currency:        d.Curreny,
 			PartnerID:       d.PartnerID,
 			PaymentMethodID: d.PaymentMethodID,
+			Money:           NewMoney(d.Amount, d.Curreny),
 		}
 		deposits[i] = deposit
 	}
@@ -2,6 +2,9 @@ package backoffice
 
 import (
 	"errors"
+
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
 )
 
 var (
@@ -17,5 +20,18 @@ var (
 	ErrUnexpectedStatus    = errors.New("unexpected status")
 
 	ErrRateLimited        = errors.New("rate limited")
+	ErrNoTokens           = errors.New("no usable tokens")
 	ErrMissingTokenSource = errors.New("missing token source")
+	ErrInvalidBaseURL     = endpoint.ErrInvalidBaseURL
+	ErrInvalidLanguage    = endpoint.ErrInvalidLanguage
 )
+
+// APIError describes a failed backoffice call. Status failures wrap one of the
+// sentinels above, so errors.Is(err, ErrNotFound) keeps working.
+// Business failures, where the backoffice answers with HasError set, carry the
+// AlertMessage instead.
+type APIError = apierror.Error
+
+func newAPIError(method, path string, statusCode int, body []byte, attempts int) *APIError {
+	return apierror.New(method, path, statusCode, body, attempts, statusError(statusCode))
+}
@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package backoffice
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLockFile fails where neither flock nor LockFileEx is available, since
+// FileTokenSource cannot coordinate processes without a lock.
+func tryLockFile(f *os.File) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+func unlockFile(f *os.File) error {
+	return errors.ErrUnsupported
+}
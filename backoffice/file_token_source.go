@@ -0,0 +1,275 @@
+package backoffice
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// lockPollInterval is how often a FileTokenSource tries again for a lock
+// another process holds.
+const lockPollInterval = 10 * time.Millisecond
+
+// fileFlushInterval is how often Token writes the counts it keeps in memory.
+const fileFlushInterval = time.Second
+
+// FileTokenSource is a PoolTokenSource whose state lives in a file, so
+// processes on the same host that share tokens also share which of them are
+// cooling down. Token reads the file without locking it and picks a token
+// from the state there, going round robin in each process on its own.
+// TokenRejected locks the file, updates it and replaces the file with the
+// new state. Uses and TokenAccepted calls are counted in memory and written
+// with the next update, by Token at most once every second, or by Flush.
+// Tokens are stored by hash, never in the clear.
+//
+// The lock is held on a separate path + ".lock" file, with flock on Unix and
+// LockFileEx on Windows. Elsewhere every call that writes fails with
+// errors.ErrUnsupported.
+type FileTokenSource struct {
+	path   string
+	tokens []string
+	opts   PoolOptions
+	now    func() time.Time
+
+	mu   sync.Mutex
+	next int
+	// pending holds the uses and acceptances per token not yet written.
+	pending map[string]TokenStats
+	flushed time.Time
+}
+
+// fileTokenState is the file format. Tokens not in this process's list are
+// kept as they are, since other processes may still use them.
+type fileTokenState struct {
+	Tokens map[string]TokenStats `json:"tokens"`
+}
+
+func NewFileTokenSource(path string, tokens []string, opts PoolOptions) *FileTokenSource {
+	seen := make(map[string]bool, len(tokens))
+	var unique []string
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			unique = append(unique, token)
+		}
+	}
+	return &FileTokenSource{
+		path:    path,
+		tokens:  unique,
+		opts:    opts.withDefaults(),
+		now:     time.Now,
+		pending: make(map[string]TokenStats),
+		flushed: time.Now(),
+	}
+}
+
+// Token picks a token without locking or writing the file, unless counts
+// have been waiting in memory for longer than a second.
+func (s *FileTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	now := s.now()
+	token, err := pickToken(s.merge(s.read()), &s.next, s.opts.Strategy, now)
+	if err == nil {
+		p := s.pending[token]
+		p.Uses++
+		p.LastUsed = now
+		s.pending[token] = p
+	}
+	flush := err == nil && now.Sub(s.flushed) >= fileFlushInterval
+	s.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+	if flush {
+		if err := s.Flush(ctx); err != nil {
+			return "", err
+		}
+	}
+	return token, nil
+}
+
+// MarkLimited puts a token in cooldown for PoolOptions.Cooldown in every
+// process sharing the file.
+func (s *FileTokenSource) MarkLimited(ctx context.Context, token string) error {
+	return s.TokenRejected(ctx, token, TokenRejection{StatusCode: http.StatusTooManyRequests})
+}
+
+func (s *FileTokenSource) TokenRejected(ctx context.Context, token string, rejection TokenRejection) error {
+	return s.update(ctx, func(tokens []*TokenStats) error {
+		for _, t := range tokens {
+			if t.Token == token {
+				rejectToken(t, rejection, s.opts, s.now())
+			}
+		}
+		return nil
+	})
+}
+
+// TokenAccepted counts the success in memory, so a successful request does
+// not touch the file.
+func (s *FileTokenSource) TokenAccepted(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pending[token]
+	p.Accepted++
+	s.pending[token] = p
+	return nil
+}
+
+// Flush writes the counts kept in memory to the file. Call it before the
+// process exits so they are not lost.
+func (s *FileTokenSource) Flush(ctx context.Context) error {
+	return s.update(ctx, func([]*TokenStats) error { return nil })
+}
+
+// Stats returns the shared state of this source's tokens, in the order they
+// were given, after writing the counts kept in memory.
+func (s *FileTokenSource) Stats() ([]TokenStats, error) {
+	var out []TokenStats
+	err := s.update(context.Background(), func(tokens []*TokenStats) error {
+		out = make([]TokenStats, len(tokens))
+		for i, t := range tokens {
+			out[i] = *t
+		}
+		return nil
+	})
+	return out, err
+}
+
+// merge returns this source's tokens as in state, with the counts kept in
+// memory added.
+func (s *FileTokenSource) merge(state fileTokenState) []*TokenStats {
+	tokens := make([]*TokenStats, len(s.tokens))
+	for i, token := range s.tokens {
+		t := state.Tokens[tokenKey(token)]
+		t.Token = token
+		if p, ok := s.pending[token]; ok {
+			t.Uses += p.Uses
+			if p.LastUsed.After(t.LastUsed) {
+				t.LastUsed = p.LastUsed
+			}
+			if p.Accepted > 0 {
+				t.Accepted += p.Accepted
+				t.Unauthorized = 0
+			}
+		}
+		tokens[i] = &t
+	}
+	return tokens
+}
+
+// update runs fn on the state read from the file while holding the lock and
+// writes the state back if it changed, even when fn fails. The new state
+// goes to a temporary file that replaces the old one, so a crash mid-write
+// leaves the previous state intact.
+func (s *FileTokenSource) update(ctx context.Context, fn func(tokens []*TokenStats) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open token state lock: %w", err)
+	}
+	defer lock.Close()
+	if err := lockFile(ctx, lock); err != nil {
+		return fmt.Errorf("failed to lock token state: %w", err)
+	}
+	defer unlockFile(lock)
+
+	state := s.read()
+	before, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tokens := s.merge(state)
+	fnErr := fn(tokens)
+	for _, t := range tokens {
+		state.Tokens[tokenKey(t.Token)] = *t
+	}
+	after, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(before, after) {
+		if err := s.write(after); err != nil {
+			return fmt.Errorf("failed to write token state: %w", err)
+		}
+	}
+	clear(s.pending)
+	s.flushed = s.now()
+	return fnErr
+}
+
+// read returns the state in the file. A missing or unreadable file starts
+// over from an empty state, which the next write replaces, rather than
+// failing every call from then on.
+func (s *FileTokenSource) read() fileTokenState {
+	var state fileTokenState
+	if raw, err := os.ReadFile(s.path); err == nil {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			state = fileTokenState{}
+		}
+	}
+	if state.Tokens == nil {
+		state.Tokens = make(map[string]TokenStats)
+	}
+	return state
+}
+
+func (s *FileTokenSource) write(raw []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// lockFile takes the lock on f, trying again every lockPollInterval while
+// another process holds it, until ctx is done.
+func lockFile(ctx context.Context, f *os.File) error {
+	for {
+		ok, err := tryLockFile(f)
+		if ok || err != nil {
+			return err
+		}
+		timer := time.NewTimer(lockPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	_ TokenSource   = &FileTokenSource{}
+	_ TokenFeedback = &FileTokenSource{}
+)
@@ -0,0 +1,193 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Two sources on the same file stand in for two processes.
+func TestFileTokenSource_SharedAcrossSources(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	tokens := []string{"token-1", "token-2"}
+	a := NewFileTokenSource(path, tokens, PoolOptions{Cooldown: time.Minute})
+	b := NewFileTokenSource(path, tokens, PoolOptions{Cooldown: time.Minute})
+
+	if token, err := a.Token(ctx); err != nil || token != "token-1" {
+		t.Fatalf("got %q, %v, want token-1", token, err)
+	}
+	if err := a.MarkLimited(ctx, "token-1"); err != nil {
+		t.Fatalf("failed to mark token: %v", err)
+	}
+	for range 2 {
+		if token, err := b.Token(ctx); err != nil || token != "token-2" {
+			t.Fatalf("got %q, %v, want token-2 while token-1 cools down", token, err)
+		}
+	}
+	if err := b.MarkLimited(ctx, "token-2"); err != nil {
+		t.Fatalf("failed to mark token: %v", err)
+	}
+	if _, err := a.Token(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, want %v", err, ErrRateLimited)
+	}
+
+	stats, err := b.Stats()
+	if err != nil {
+		t.Fatalf("failed to read stats: %v", err)
+	}
+	if stats[0].Limited != 1 || stats[0].Uses != 1 || stats[1].Uses != 2 {
+		t.Fatalf("got stats %+v", stats)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read state: %v", err)
+	}
+	if strings.Contains(string(raw), "token-1") {
+		t.Fatalf("state file holds a token in the clear: %s", raw)
+	}
+}
+
+func TestFileTokenSource_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	tokens := []string{"token-1", "token-2", "token-3"}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		s := NewFileTokenSource(path, tokens, PoolOptions{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				if _, err := s.Token(ctx); err != nil {
+					t.Errorf("failed to get token: %v", err)
+					return
+				}
+			}
+			if err := s.Flush(ctx); err != nil {
+				t.Errorf("failed to flush: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats, err := NewFileTokenSource(path, tokens, PoolOptions{}).Stats()
+	if err != nil {
+		t.Fatalf("failed to read stats: %v", err)
+	}
+	uses := 0
+	for _, s := range stats {
+		uses += s.Uses
+	}
+	if uses != 100 {
+		t.Fatalf("got %d uses recorded, want 100", uses)
+	}
+}
+
+// A state file cut short, as by a crash of an older writer, must not fail
+// every call from then on.
+func TestFileTokenSource_TruncatedState(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(path, []byte(`{"next":1,"tokens":{"ab`), 0o600); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	s := NewFileTokenSource(path, []string{"token-1"}, PoolOptions{})
+	if token, err := s.Token(ctx); err != nil || token != "token-1" {
+		t.Fatalf("got %q, %v, want token-1", token, err)
+	}
+	if err := s.TokenAccepted(ctx, "token-1"); err != nil {
+		t.Fatalf("failed to accept token: %v", err)
+	}
+	stats, err := s.Stats()
+	if err != nil {
+		t.Fatalf("failed to read stats: %v", err)
+	}
+	if stats[0].Uses != 1 || stats[0].Accepted != 1 {
+		t.Fatalf("got stats %+v, want one use and one acceptance", stats)
+	}
+	if matches, _ := filepath.Glob(path + ".*.tmp"); len(matches) != 0 {
+		t.Fatalf("got leftover temporary files %v", matches)
+	}
+}
+
+func TestFileTokenSource_LockHonorsContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open lock: %v", err)
+	}
+	defer lock.Close()
+	if err := lockFile(context.Background(), lock); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	defer unlockFile(lock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	s := NewFileTokenSource(path, []string{"token-1"}, PoolOptions{})
+	if err := s.MarkLimited(ctx, "token-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v while another process holds the lock", err, context.DeadlineExceeded)
+	}
+}
+
+// Token must not lock or write the file while nothing needs writing, and an
+// update that changes nothing must leave the file alone.
+func TestFileTokenSource_TokenSkipsWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	s := NewFileTokenSource(path, []string{"token-1", "token-2"}, PoolOptions{})
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	written, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat state: %v", err)
+	}
+
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open lock: %v", err)
+	}
+	defer lock.Close()
+	if err := lockFile(context.Background(), lock); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for range 10 {
+		if _, err := s.Token(ctx); err != nil {
+			t.Fatalf("got error %v, want a token while another process holds the lock", err)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || !os.SameFile(info, written) {
+		t.Fatalf("state file was replaced by Token")
+	}
+	unlockFile(lock)
+
+	stats, err := s.Stats()
+	if err != nil {
+		t.Fatalf("failed to read stats: %v", err)
+	}
+	if stats[0].Uses != 5 || stats[1].Uses != 5 {
+		t.Fatalf("got stats %+v, want the uses written once flushed", stats)
+	}
+	flushed, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat state: %v", err)
+	}
+	if _, err := s.Stats(); err != nil {
+		t.Fatalf("failed to read stats: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || !os.SameFile(info, flushed) {
+		t.Fatalf("state file was replaced with nothing changed")
+	}
+}
@@ -20,6 +20,7 @@ type ListTransactionsRequest struct {
 	FromDate *ListTransactionsRequestDate `json:"FromCreatedDateLocal"`
 	ToDate   *ListTransactionsRequestDate `json:"ToCreatedDateLocal"`
 	MaxRows  int                          `json:"MaxRows"`
+	Offset   int                          `json:"SkeepRows,omitempty"`
 }
 
 type listTransactionsResponse struct {
@@ -33,6 +34,7 @@ func (c *client) ListTransactions(ctx context.Context, req ListTransactionsReque
 	}
 	transactions, err := makeRequest[listTransactionsResponse](
 		ctx,
+		"ListTransactions",
 		http.MethodPost,
 		"/Financial/GetDocumentsWithPaging",
 		body,
@@ -0,0 +1,106 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	tr := &headerTransport{stubTransport: stubTransport{responses: []stubResponse{
+		{status: http.StatusForbidden},
+		{status: http.StatusOK, body: playerBody},
+	}}}
+	ts := &stubTokenSource{tokens: []string{"token-1", "token-2"}}
+
+	var order []string
+	var calls []Call
+	var outcomes []Outcome
+	record := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) Outcome {
+			order = append(order, "record")
+			out := next.Do(ctx, call)
+			calls, outcomes = append(calls, *call), append(outcomes, out)
+			return out
+		})
+	}
+	trace := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) Outcome {
+			order = append(order, "trace")
+			call.Header.Set("Traceparent", "00-trace")
+			return next.Do(ctx, call)
+		})
+	}
+	c := newTestClient(t, tr, WithTokenSource(ts), WithMiddleware(record), WithMiddleware(trace))
+
+	if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	if len(order) != 2 || order[0] != "record" || order[1] != "trace" {
+		t.Fatalf("got middleware order %v, want record then trace", order)
+	}
+	call, out := calls[0], outcomes[0]
+	if call.Operation != "GetPlayer" || call.Path != "/Client/GetClientById?id=42" {
+		t.Fatalf("got call %+v", call)
+	}
+	if out.Err != nil || out.StatusCode != http.StatusOK || out.Token != "token-2" || out.Attempts != 2 || out.BodySize != int64(len(playerBody)) {
+		t.Fatalf("got outcome %+v", out)
+	}
+	for i, h := range tr.headers {
+		if h != "00-trace" {
+			t.Fatalf("request %d went out with traceparent %q", i, h)
+		}
+	}
+
+	fault := errors.New("injected")
+	c = newTestClient(t, tr, WithAuthToken("token-1"), WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) Outcome {
+			return Outcome{Err: fault}
+		})
+	}))
+	sent := len(tr.calls())
+	if _, err := c.GetPlayer(context.Background(), 42); !errors.Is(err, fault) {
+		t.Fatalf("got error %v, want the injected one", err)
+	}
+	if len(tr.calls()) != sent {
+		t.Fatal("sent a request the middleware answered")
+	}
+}
+
+// headerTransport is a stubTransport that also records the traceparent of
+// every request.
+type headerTransport struct {
+	stubTransport
+	headers []string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.headers = append(t.headers, req.Header.Get("Traceparent"))
+	return t.stubTransport.RoundTrip(req)
+}
+
+// ListWithdrawals shares its endpoint with ListWithdrawalsWithTotals but is
+// its own operation.
+func TestWithMiddleware_ListWithdrawals(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{
+		{status: http.StatusOK, body: `{"Data":{"ClientRequests":[{"Id":7,"State":0}]}}`},
+	}}
+	var ops []string
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) Outcome {
+			ops = append(ops, call.Operation)
+			return next.Do(ctx, call)
+		})
+	}))
+
+	if _, err := c.ListWithdrawals(context.Background(), ListWithdrawalsRequest{}); err != nil {
+		t.Fatalf("failed to list withdrawals: %v", err)
+	}
+	if _, err := c.ListWithdrawalsWithTotals(context.Background(), ListWithdrawalsRequest{}); err != nil {
+		t.Fatalf("failed to list withdrawals: %v", err)
+	}
+	if len(ops) != 2 || ops[0] != "ListWithdrawals" || ops[1] != "ListWithdrawalsWithTotals" {
+		t.Fatalf("got operations %v, want ListWithdrawals then ListWithdrawalsWithTotals", ops)
+	}
+}
@@ -0,0 +1,102 @@
+package backoffice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/extrasoftorg/betconstruct/internal/decimal"
+)
+
+// Decimal is an exact base-10 number. It holds up to 18 significant digits,
+// which is plenty for amounts. Arithmetic whose exact result needs more digits
+// returns ErrDecimalOverflow instead of silently losing precision. The zero
+// value is 0.
+type Decimal = decimal.Decimal
+
+var (
+	ErrInvalidDecimal   = decimal.ErrInvalid
+	ErrDecimalOverflow  = decimal.ErrOverflow
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// NewDecimal returns coef × 10^-scale, e.g. NewDecimal(1050, 2) is 10.50. It
+// panics when the value does not fit in 18 digits, like a malformed literal.
+func NewDecimal(coef int64, scale int32) Decimal {
+	return decimal.New(coef, scale)
+}
+
+// ParseDecimal parses a plain or exponent decimal literal such as "-12.50" or
+// "1.5e3". Fractional digits beyond the 18 significant digits a Decimal holds
+// are rounded half away from zero; a larger integer part is an error.
+func ParseDecimal(s string) (Decimal, error) {
+	return decimal.Parse(s)
+}
+
+// DecimalFromFloat converts f using its shortest decimal representation, so
+// amounts that were decoded from a JSON number convert back exactly.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return decimal.FromFloat(f)
+}
+
+// Money is an exact amount in an ISO 4217 currency. The currency may be empty
+// when the backoffice does not report one.
+type Money struct {
+	Amount   Decimal `json:"Amount"`
+	Currency string  `json:"Currency"`
+}
+
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns m + o. The zero Money adopts the currency of o, so a total can
+// start from the zero value; otherwise both currencies must match.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.currencyWith(o)
+	if err != nil {
+		return Money{}, err
+	}
+	amount, err := m.Amount.Add(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.currencyWith(o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+	return m.Amount.String() + " " + m.Currency
+}
+
+func (m Money) currencyWith(o Money) (string, error) {
+	switch {
+	case m.Currency == o.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.IsZero():
+		return o.Currency, nil
+	case o.Currency == "" && o.IsZero():
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+}
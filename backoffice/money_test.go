@@ -0,0 +1,86 @@
+package backoffice
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMoney_Add(t *testing.T) {
+	var total Money
+	var err error
+	for _, amount := range []int64{1999, 1} {
+		total, err = total.Add(NewMoney(NewDecimal(amount, 2), "TRY"))
+		if err != nil {
+			t.Fatalf("failed to add: %v", err)
+		}
+	}
+	if total.String() != "20.00 TRY" {
+		t.Fatalf("got %s, want 20.00 TRY", total)
+	}
+
+	if _, err := total.Add(NewMoney(NewDecimal(1, 0), "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("got error %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	tiny, _ := ParseDecimal("0.000000000000000001")
+	if _, err := NewMoney(NewDecimal(100, 0), "TRY").Sub(NewMoney(tiny, "TRY")); !errors.Is(err, ErrDecimalOverflow) {
+		t.Fatalf("got error %v, want %v", err, ErrDecimalOverflow)
+	}
+}
+
+func TestWithdrawal_UnmarshalMoney(t *testing.T) {
+	var w Withdrawal
+	body := `{"Id": 1, "Amount": "100.10", "CurrencyId": "TRY", "RequestTimeLocal": "2025-03-01T12:00:00", "State": 0}`
+	if err := json.Unmarshal([]byte(body), &w); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if w.Amount != 100.10 || w.Money.String() != "100.10 TRY" || w.Status != WithdrawalStatusPending {
+		t.Fatalf("got %+v", w)
+	}
+}
+
+// A marshalled withdrawal keeps its exact Money when read back.
+func TestWithdrawal_MoneyRoundTrip(t *testing.T) {
+	w := Withdrawal{ID: 1, Amount: 0.3, Money: NewMoney(NewDecimal(3, 1), "TRY"), Status: WithdrawalStatusPaid}
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var got struct {
+		Money Money
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", b, err)
+	}
+	if got.Money != w.Money {
+		t.Fatalf("got %v, want %v", got.Money, w.Money)
+	}
+}
+
+func TestPlayerBonus_UnmarshalMoney(t *testing.T) {
+	var bonuses []PlayerBonus
+	body := `[
+		{"Id": 1, "Amount": "25.50", "CreatedLocal": "2025-03-01T12:00:00"},
+		{"Id": 2, "Amount": 0, "Count": 10, "CreatedLocal": "2025-03-01T12:00:00"}
+	]`
+	if err := json.Unmarshal([]byte(body), &bonuses); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if bonuses[0].Money.String() != "25.50" || bonuses[0].Amount != 25.5 {
+		t.Fatalf("got %+v, want 25.50", bonuses[0])
+	}
+	if bonuses[1].Money.String() != "10" || bonuses[1].Amount != 10 {
+		t.Fatalf("got %+v, want the count of 10", bonuses[1])
+	}
+}
+
+func TestWithdrawalTotals_UnmarshalMoney(t *testing.T) {
+	var totals WithdrawalTotals
+	if err := json.Unmarshal([]byte(`{"Count": 2, "Amount": "0.30"}`), &totals); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if totals.Count != 2 || totals.Money.String() != "0.30" {
+		t.Fatalf("got %+v, want 2 totalling 0.30", totals)
+	}
+}
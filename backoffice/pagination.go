@@ -0,0 +1,165 @@
+package backoffice
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+// defaultPageSize is the page size the iterators use when the input does not
+// set one.
+const defaultPageSize = 100
+
+type pageConfig struct {
+	prefetch bool
+}
+
+type PageOption func(c *pageConfig)
+
+// WithPrefetch fetches the next page while the current one is being consumed.
+func WithPrefetch() PageOption {
+	return func(c *pageConfig) {
+		c.prefetch = true
+	}
+}
+
+// AllDeposits iterates over every deposit matching in, page by page. in.Limit
+// sets the page size and in.Offset where to start.
+func AllDeposits(ctx context.Context, c Client, in ListDepositsInput, opts ...PageOption) iter.Seq2[Deposit, error] {
+	return paginate(ctx, in.Offset, in.Limit, opts, func(ctx context.Context, offset, limit int) ([]Deposit, int, error) {
+		page := in
+		page.Offset, page.Limit = offset, limit
+		out, err := c.ListDeposits(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return out.Deposits, out.Count, nil
+	})
+}
+
+// AllWithdrawals iterates over every withdrawal matching req, page by page.
+// req.Limit sets the page size and req.Offset where to start.
+func AllWithdrawals(ctx context.Context, c Client, req ListWithdrawalsRequest, opts ...PageOption) iter.Seq2[Withdrawal, error] {
+	return paginate(ctx, req.Offset, req.Limit, opts, func(ctx context.Context, offset, limit int) ([]Withdrawal, int, error) {
+		page := req
+		page.Offset, page.Limit = offset, limit
+		out, err := c.ListWithdrawalsWithTotals(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return out.Withdrawals, out.Totals.Count, nil
+	})
+}
+
+// AllTransactions iterates over every transaction matching req, page by page.
+// req.MaxRows sets the page size and req.Offset where to start.
+func AllTransactions(ctx context.Context, c Client, req ListTransactionsRequest, opts ...PageOption) iter.Seq2[Transaction, error] {
+	return paginate(ctx, req.Offset, req.MaxRows, opts, func(ctx context.Context, offset, limit int) ([]Transaction, int, error) {
+		page := req
+		page.Offset, page.MaxRows = offset, limit
+		transactions, err := c.ListTransactions(ctx, page)
+		return transactions, -1, err
+	})
+}
+
+// AllPlayers iterates over every player matching req, page by page.
+// req.MaxRows sets the page size and req.Offset where to start.
+func AllPlayers(ctx context.Context, c Client, req ListPlayersRequest, opts ...PageOption) iter.Seq2[*ListPlayersPlayer, error] {
+	return paginate(ctx, req.Offset, req.MaxRows, opts, func(ctx context.Context, offset, limit int) ([]*ListPlayersPlayer, int, error) {
+		page := req
+		page.Offset, page.MaxRows = offset, limit
+		players, err := c.ListPlayers(ctx, page)
+		return players, -1, err
+	})
+}
+
+// AllPromoCodes iterates over every promo code matching in, page by page.
+// in.Limit sets the page size and in.Offset where to start.
+func AllPromoCodes(ctx context.Context, c Client, in ListPromoCodesInput, opts ...PageOption) iter.Seq2[PromoCode, error] {
+	return paginate(ctx, in.Offset, in.Limit, opts, func(ctx context.Context, offset, limit int) ([]PromoCode, int, error) {
+		page := in
+		page.Offset, page.Limit = offset, limit
+		promoCodes, err := c.ListPromoCodes(ctx, page)
+		return promoCodes, -1, err
+	})
+}
+
+// fetchPage loads limit items starting at offset. It returns the total number
+// of items, or -1 when the endpoint does not report one.
+type fetchPage[T any] func(ctx context.Context, offset, limit int) ([]T, int, error)
+
+type pageResult[T any] struct {
+	items []T
+	total int
+	err   error
+}
+
+// paginate walks the pages returned by fetch until a short or empty page, the
+// reported total or an error. Without a total, a page equal to the one before
+// it also ends the walk, since it means the endpoint ignored the offset. It
+// stops early when ctx is done or the consumer breaks.
+// The returned sequence can be ranged over more than once; each range starts
+// again at start.
+func paginate[T any](ctx context.Context, start, limit int, opts []PageOption, fetch fetchPage[T]) iter.Seq2[T, error] {
+	var cfg pageConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		offset := start
+
+		load := func(offset int) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			go func() {
+				items, total, err := fetch(ctx, offset, limit)
+				ch <- pageResult[T]{items: items, total: total, err: err}
+			}()
+			return ch
+		}
+
+		var next <-chan pageResult[T]
+		var prev []T
+		for {
+			var res pageResult[T]
+			if next != nil {
+				res = <-next
+				next = nil
+			} else if err := ctx.Err(); err != nil {
+				res.err = err
+			} else {
+				res.items, res.total, res.err = fetch(ctx, offset, limit)
+			}
+			if res.err != nil {
+				var zero T
+				yield(zero, res.err)
+				return
+			}
+
+			if res.total < 0 && len(res.items) > 0 && reflect.DeepEqual(res.items, prev) {
+				return
+			}
+			prev = res.items
+
+			offset += len(res.items)
+			last := len(res.items) < limit || (res.total >= 0 && offset >= res.total)
+			if !last && cfg.prefetch {
+				next = load(offset)
+			}
+
+			for _, item := range res.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if last {
+				return
+			}
+		}
+	}
+}
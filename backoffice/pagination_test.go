@@ -0,0 +1,182 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// pages serves items in pages and counts the fetches.
+type pages struct {
+	items   []int
+	total   int
+	fetches atomic.Int32
+	err     error
+}
+
+func (p *pages) fetch(ctx context.Context, offset, limit int) ([]int, int, error) {
+	p.fetches.Add(1)
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if p.err != nil {
+		return nil, 0, p.err
+	}
+	end := min(offset+limit, len(p.items))
+	if offset >= end {
+		return nil, p.total, nil
+	}
+	return p.items[offset:end], p.total, nil
+}
+
+func collect(t *testing.T, seq func(yield func(int, error) bool)) []int {
+	t.Helper()
+
+	var got []int
+	for item, err := range seq {
+		if err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		got = append(got, item)
+	}
+	return got
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name        string
+		total       int
+		opts        []PageOption
+		wantFetches int32
+	}{
+		{"short last page", -1, nil, 3},
+		{"reported total", len(items), nil, 3},
+		{"prefetch", -1, []PageOption{WithPrefetch()}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pages{items: items, total: tt.total}
+			got := collect(t, paginate(context.Background(), 0, 3, tt.opts, p.fetch))
+			if !slices.Equal(got, items) {
+				t.Fatalf("got %v, want %v", got, items)
+			}
+			if n := p.fetches.Load(); n != tt.wantFetches {
+				t.Fatalf("made %d fetches, want %d", n, tt.wantFetches)
+			}
+		})
+	}
+}
+
+// Each range over the same sequence starts again from the first page.
+func TestPaginate_RangeTwice(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		p := &pages{items: items, total: -1}
+		seq := paginate(context.Background(), 1, 2, opts, p.fetch)
+
+		for i := range 2 {
+			if got := collect(t, seq); !slices.Equal(got, items[1:]) {
+				t.Fatalf("range %d: got %v, want %v", i+1, got, items[1:])
+			}
+		}
+	}
+}
+
+// A page exactly as long as the page size needs one more fetch to know it was
+// the last, unless the total says so.
+func TestPaginate_ExactPages(t *testing.T) {
+	p := &pages{items: []int{1, 2, 3, 4}, total: -1}
+	if got := collect(t, paginate(context.Background(), 0, 2, nil, p.fetch)); len(got) != 4 {
+		t.Fatalf("got %v, want 4 items", got)
+	}
+	if n := p.fetches.Load(); n != 3 {
+		t.Fatalf("made %d fetches, want 3", n)
+	}
+
+	p = &pages{items: []int{1, 2, 3, 4}, total: 4}
+	collect(t, paginate(context.Background(), 0, 2, nil, p.fetch))
+	if n := p.fetches.Load(); n != 2 {
+		t.Fatalf("made %d fetches, want 2", n)
+	}
+}
+
+func TestPaginate_Stops(t *testing.T) {
+	t.Run("break", func(t *testing.T) {
+		p := &pages{items: []int{1, 2, 3, 4, 5}, total: -1}
+		for item := range paginate(context.Background(), 0, 2, nil, p.fetch) {
+			if item == 2 {
+				break
+			}
+		}
+		if n := p.fetches.Load(); n != 1 {
+			t.Fatalf("made %d fetches, want 1", n)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p := &pages{err: ErrServiceUnavailable}
+		var gotErr error
+		for _, err := range paginate(context.Background(), 0, 2, nil, p.fetch) {
+			gotErr = err
+		}
+		if !errors.Is(gotErr, ErrServiceUnavailable) {
+			t.Fatalf("got error %v, want %v", gotErr, ErrServiceUnavailable)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		p := &pages{items: []int{1, 2, 3, 4, 5}, total: -1}
+
+		var gotErr error
+		for item, err := range paginate(ctx, 0, 2, nil, p.fetch) {
+			if err != nil {
+				gotErr = err
+				break
+			}
+			if item == 2 {
+				cancel()
+			}
+		}
+		if !errors.Is(gotErr, context.Canceled) {
+			t.Fatalf("got error %v, want %v", gotErr, context.Canceled)
+		}
+		if n := p.fetches.Load(); n != 1 {
+			t.Fatalf("made %d fetches, want 1", n)
+		}
+	})
+}
+
+// An endpoint that ignores SkeepRows serves the first page forever; the
+// iterator must stop after it instead of looping.
+func TestAllPromoCodes_IgnoredOffset(t *testing.T) {
+	promo := func(id int) string {
+		return fmt.Sprintf(`{"Id":%d,"Code":"P%d","Created":"2025-03-01T12:00:00Z","EndDateLocal":"01-04-25 - 00:00:00","StartDateLocal":"01-03-25 - 00:00:00"}`, id, id)
+	}
+	page := fmt.Sprintf(`{"Data":{"Count":2,"Objects":[%s,%s]}}`, promo(1), promo(2))
+
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		tr := &stubTransport{responses: []stubResponse{{status: http.StatusOK, body: page}}}
+		c := newTestClient(t, tr, WithAuthToken("token-1"))
+
+		var got []int64
+		for pc, err := range AllPromoCodes(context.Background(), c, ListPromoCodesInput{Limit: 2}, opts...) {
+			if err != nil {
+				t.Fatalf("iteration failed: %v", err)
+			}
+			got = append(got, pc.ID)
+		}
+		if !slices.Equal(got, []int64{1, 2}) {
+			t.Fatalf("got promo codes %v, want [1 2]", got)
+		}
+	}
+}
@@ -30,6 +30,7 @@ func (c *client) ListRegisteredPlayers(ctx context.Context, req ListRegisteredPl
 	}
 	players, err := makeRequest[listRegisteredPlayersResponse](
 		ctx,
+		"ListRegisteredPlayers",
 		http.MethodPost,
 		"/Client/GetClientRegistrationStatisticsDetails",
 		body,
@@ -45,6 +46,7 @@ type ListPlayersRequest struct {
 	FromRegistrationDate time.Time
 	ToRegistrationDate   time.Time
 	MaxRows              int
+	Offset               int
 	Username             string
 	Phone                string
 }
@@ -54,13 +56,15 @@ func (c *client) ListPlayers(ctx context.Context, req ListPlayersRequest) ([]*Li
 		FromRegistrationDate *string `json:"MinCreatedLocal"`
 		ToRegistrationDate   *string `json:"MaxCreatedLocal"`
 		MaxRows              int     `json:"MaxRows"`
+		SkeepRows            int     `json:"SkeepRows,omitempty"`
 		Username             string  `json:"Login"`
 		Phone                string  `json:"Phone"`
 	}
 	p := payload{
-		MaxRows:  req.MaxRows,
-		Username: req.Username,
-		Phone:    req.Phone,
+		MaxRows:   req.MaxRows,
+		SkeepRows: req.Offset,
+		Username:  req.Username,
+		Phone:     req.Phone,
 	}
 	if !req.FromRegistrationDate.IsZero() {
 		req.FromRegistrationDate = req.FromRegistrationDate.In(c.timeLocation)
@@ -94,6 +98,7 @@ func (c *client) ListPlayers(ctx context.Context, req ListPlayersRequest) ([]*Li
 	}
 	players, err := makeRequest[response](
 		ctx,
+		"ListPlayers",
 		http.MethodPost,
 		"/Client/GetClients",
 		body,
@@ -145,12 +150,13 @@ func (c *client) ListPlayers(ctx context.Context, req ListPlayersRequest) ([]*Li
 func (c *client) GetPlayerKPI(ctx context.Context, playerID PlayerID) (*PlayerKPI, error) {
 	type response struct {
 		PlayerID              PlayerID `json:"ClientId"`
-		TotalDepositAmount    float64  `json:"DepositAmount"`
+		TotalDepositAmount    Decimal  `json:"DepositAmount"`
 		DepositCount          int32    `json:"DepositCount"`
-		TotalWithdrawalAmount float64  `json:"WithdrawalAmount"`
+		TotalWithdrawalAmount Decimal  `json:"WithdrawalAmount"`
 		WithdrawalCount       int32    `json:"WithdrawalCount"`
-		LastDepositAmount     float64  `json:"LastDepositAmount"`
-		LastWithdrawalAmount  float64  `json:"LastWithdrawalAmount"`
+		LastDepositAmount     Decimal  `json:"LastDepositAmount"`
+		LastWithdrawalAmount  Decimal  `json:"LastWithdrawalAmount"`
+		Currency              string   `json:"CurrencyId"`
 		FirstDepositAt        *string  `json:"FirstDepositTimeLocal"`
 		LastDepositAt         *string  `json:"LastDepositTimeLocal"`
 		LastWithdrawalAt      *string  `json:"LastWithdrawalTimeLocal"`
@@ -160,6 +166,7 @@ func (c *client) GetPlayerKPI(ctx context.Context, playerID PlayerID) (*PlayerKP
 
 	resp, err := makeRequest[response](
 		ctx,
+		"GetPlayerKPI",
 		http.MethodGet,
 		fmt.Sprintf("/Client/GetClientKpi?id=%d", playerID),
 		nil,
@@ -171,12 +178,16 @@ func (c *client) GetPlayerKPI(ctx context.Context, playerID PlayerID) (*PlayerKP
 
 	kpi := &PlayerKPI{
 		PlayerID:              resp.PlayerID,
-		TotalDepositAmount:    resp.TotalDepositAmount,
+		TotalDepositAmount:    resp.TotalDepositAmount.Float64(),
 		DepositCount:          resp.DepositCount,
-		TotalWithdrawalAmount: resp.TotalWithdrawalAmount,
+		TotalWithdrawalAmount: resp.TotalWithdrawalAmount.Float64(),
 		WithdrawalCount:       resp.WithdrawalCount,
-		LastDepositAmount:     resp.LastDepositAmount,
-		LastWithdrawalAmount:  resp.LastWithdrawalAmount,
+		LastDepositAmount:     resp.LastDepositAmount.Float64(),
+		LastWithdrawalAmount:  resp.LastWithdrawalAmount.Float64(),
+		TotalDepositMoney:     NewMoney(resp.TotalDepositAmount, resp.Currency),
+		TotalWithdrawalMoney:  NewMoney(resp.TotalWithdrawalAmount, resp.Currency),
+		LastDepositMoney:      NewMoney(resp.LastDepositAmount, resp.Currency),
+		LastWithdrawalMoney:   NewMoney(resp.LastWithdrawalAmount, resp.Currency),
 	}
 
 	if resp.FirstDepositAt != nil {
@@ -235,6 +246,7 @@ type GetClientRestrictionResult struct {
 func (c *client) GetClientRestriction(ctx context.Context, playerID PlayerID) (*GetClientRestrictionResult, error) {
 	restriction, err := makeRequest[GetClientRestrictionResult](
 		ctx,
+		"GetClientRestriction",
 		http.MethodGet,
 		fmt.Sprintf("/Client/GetClientRestriction?clientId=%d", playerID),
 		nil,
@@ -264,8 +276,9 @@ func (c *client) SaveClientRestriction(ctx context.Context, req SaveClientRestri
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](
+	_, err = makeMutatingRequest[any](
 		ctx,
+		"SaveClientRestriction",
 		http.MethodPost,
 		"/Client/SaveClientRestriction",
 		body,
@@ -282,10 +295,14 @@ const (
 
 type AddPaymentToPlayerRequest struct {
 	PlayerID PlayerID
-	Amount   float64
-	Note     string
-	Type     AddPaymentToPlayerRequestType
+	// Deprecated: Amount is sent rounded to two places; set Money instead.
+	Amount float64
+	Note   string
+	Type   AddPaymentToPlayerRequestType
+	// Deprecated: set Money instead.
 	Currency string
+	// Money, when set, is sent instead of Amount and Currency.
+	Money *Money
 }
 
 func (a AddPaymentToPlayerRequest) MarshalJSON() ([]byte, error) {
@@ -301,6 +318,10 @@ func (a AddPaymentToPlayerRequest) MarshalJSON() ([]byte, error) {
 		Currency: a.Currency,
 		Amount:   fmt.Sprintf("%.2f", a.Amount),
 	}
+	if a.Money != nil {
+		w.Amount = a.Money.Amount.String()
+		w.Currency = a.Money.Currency
+	}
 	switch a.Type {
 	case AddPaymentToPlayerRequestTypeCorrectionUp:
 		w.Type = 3
@@ -315,8 +336,9 @@ func (c *client) AddPaymentToPlayer(ctx context.Context, req AddPaymentToPlayerR
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](
+	_, err = makeMutatingRequest[any](
 		ctx,
+		"AddPaymentToPlayer",
 		http.MethodPost,
 		"/Client/CreateClientPaymentDocument",
 		body,
@@ -331,6 +353,7 @@ func (c *client) AddPaymentToPlayer(ctx context.Context, req AddPaymentToPlayerR
 func (c *client) GetPlayer(ctx context.Context, playerID PlayerID) (*Player, error) {
 	return makeRequest[Player](
 		ctx,
+		"GetPlayer",
 		http.MethodGet,
 		fmt.Sprintf("/Client/GetClientById?id=%d", playerID),
 		nil,
@@ -56,17 +56,27 @@ type Player struct {
 	Username   string   `json:"Login"`
 }
 
+// PlayerKPI holds a player's totals. The float64 amounts are deprecated in
+// favour of the exact Money fields.
 type PlayerKPI struct {
-	PlayerID              PlayerID
-	TotalDepositAmount    float64
-	DepositCount          int32
+	PlayerID PlayerID
+	// Deprecated: use TotalDepositMoney.
+	TotalDepositAmount float64
+	DepositCount       int32
+	// Deprecated: use TotalWithdrawalMoney.
 	TotalWithdrawalAmount float64
 	WithdrawalCount       int32
-	LastDepositAmount     float64
-	LastWithdrawalAmount  float64
-	FirstDepositAt        time.Time
-	LastDepositAt         time.Time
-	LastWithdrawalAt      time.Time
-	LastSportBetAt        time.Time
-	LastCasinoBetAt       time.Time
+	// Deprecated: use LastDepositMoney.
+	LastDepositAmount float64
+	// Deprecated: use LastWithdrawalMoney.
+	LastWithdrawalAmount float64
+	FirstDepositAt       time.Time
+	LastDepositAt        time.Time
+	LastWithdrawalAt     time.Time
+	LastSportBetAt       time.Time
+	LastCasinoBetAt      time.Time
+	TotalDepositMoney    Money
+	TotalWithdrawalMoney Money
+	LastDepositMoney     Money
+	LastWithdrawalMoney  Money
 }
@@ -0,0 +1,198 @@
+package backoffice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPoolCooldown        = time.Minute
+	defaultPoolMaxUnauthorized = 3
+)
+
+type PoolStrategy int
+
+const (
+	// PoolRoundRobin hands out the tokens in turn.
+	PoolRoundRobin PoolStrategy = iota
+	// PoolLeastRecentlyUsed hands out the token that has been idle longest.
+	PoolLeastRecentlyUsed
+)
+
+type PoolOptions struct {
+	Strategy PoolStrategy
+	// Cooldown is how long a limited token is left alone when the
+	// backoffice doesn't send a Retry-After. It defaults to a minute.
+	Cooldown time.Duration
+	// MaxUnauthorized is the number of 401s in a row after which a token is
+	// dropped from the pool for good. It defaults to 3.
+	MaxUnauthorized int
+}
+
+func (o PoolOptions) withDefaults() PoolOptions {
+	if o.Cooldown <= 0 {
+		o.Cooldown = defaultPoolCooldown
+	}
+	if o.MaxUnauthorized <= 0 {
+		o.MaxUnauthorized = defaultPoolMaxUnauthorized
+	}
+	return o
+}
+
+// TokenStats is a snapshot of how a pooled token has fared.
+type TokenStats struct {
+	Token    string `json:"-"`
+	Uses     int    `json:"uses"`
+	Accepted int    `json:"accepted"`
+	Limited  int    `json:"limited"`
+	// Unauthorized counts the 401s since the token was last accepted.
+	Unauthorized  int       `json:"unauthorized"`
+	LastUsed      time.Time `json:"lastUsed"`
+	CooldownUntil time.Time `json:"cooldownUntil"`
+	Dropped       bool      `json:"dropped"`
+}
+
+// PoolTokenSource spreads requests over several tokens. A token the
+// backoffice limits cools down before it is handed out again, and one that
+// keeps getting 401s is dropped. Token returns ErrRateLimited only when every
+// token left is cooling down, and ErrNoTokens once all of them are dropped.
+type PoolTokenSource struct {
+	mu     sync.Mutex
+	opts   PoolOptions
+	tokens []*TokenStats
+	byName map[string]*TokenStats
+	next   int
+	now    func() time.Time
+}
+
+func NewPoolTokenSource(tokens []string, opts PoolOptions) *PoolTokenSource {
+	p := &PoolTokenSource{
+		opts:   opts.withDefaults(),
+		byName: make(map[string]*TokenStats, len(tokens)),
+		now:    time.Now,
+	}
+	for _, token := range tokens {
+		if _, ok := p.byName[token]; ok {
+			continue
+		}
+		stats := &TokenStats{Token: token}
+		p.tokens = append(p.tokens, stats)
+		p.byName[token] = stats
+	}
+	return p
+}
+
+func (p *PoolTokenSource) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return pickToken(p.tokens, &p.next, p.opts.Strategy, p.now())
+}
+
+// MarkLimited puts a token in cooldown for PoolOptions.Cooldown.
+func (p *PoolTokenSource) MarkLimited(ctx context.Context, token string) error {
+	return p.TokenRejected(ctx, token, TokenRejection{StatusCode: http.StatusTooManyRequests})
+}
+
+func (p *PoolTokenSource) TokenRejected(ctx context.Context, token string, rejection TokenRejection) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.byName[token]; ok {
+		rejectToken(t, rejection, p.opts, p.now())
+	}
+	return nil
+}
+
+func (p *PoolTokenSource) TokenAccepted(ctx context.Context, token string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t, ok := p.byName[token]; ok {
+		t.Accepted++
+		t.Unauthorized = 0
+	}
+	return nil
+}
+
+// Stats returns a snapshot of every token in the pool, dropped ones
+// included, in the order they were given.
+func (p *PoolTokenSource) Stats() []TokenStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]TokenStats, len(p.tokens))
+	for i, t := range p.tokens {
+		out[i] = *t
+	}
+	return out
+}
+
+var (
+	_ TokenSource   = &PoolTokenSource{}
+	_ TokenFeedback = &PoolTokenSource{}
+)
+
+// pickToken hands out the next usable token by strategy, starting the round
+// robin at *next.
+func pickToken(tokens []*TokenStats, next *int, strategy PoolStrategy, now time.Time) (string, error) {
+	var (
+		picked    *TokenStats
+		nextReady time.Time
+		alive     bool
+	)
+	for i := range tokens {
+		idx := (*next + i) % len(tokens)
+		t := tokens[idx]
+		if t.Dropped {
+			continue
+		}
+		alive = true
+		if now.Before(t.CooldownUntil) {
+			if nextReady.IsZero() || t.CooldownUntil.Before(nextReady) {
+				nextReady = t.CooldownUntil
+			}
+			continue
+		}
+		if strategy == PoolRoundRobin {
+			picked = t
+			*next = (idx + 1) % len(tokens)
+			break
+		}
+		if picked == nil || t.LastUsed.Before(picked.LastUsed) {
+			picked = t
+		}
+	}
+
+	switch {
+	case picked != nil:
+		picked.Uses++
+		picked.LastUsed = now
+		return picked.Token, nil
+	case alive:
+		return "", fmt.Errorf("%w: every token is cooling down for another %s", ErrRateLimited, nextReady.Sub(now))
+	}
+	return "", ErrNoTokens
+}
+
+// rejectToken drops t after too many 401s in a row and otherwise puts it in
+// cooldown.
+func rejectToken(t *TokenStats, rejection TokenRejection, opts PoolOptions, now time.Time) {
+	if rejection.StatusCode == http.StatusUnauthorized {
+		t.Unauthorized++
+		if t.Unauthorized >= opts.MaxUnauthorized {
+			t.Dropped = true
+			return
+		}
+	} else {
+		t.Limited++
+	}
+
+	cooldown := rejection.RetryAfter
+	if cooldown <= 0 {
+		cooldown = opts.Cooldown
+	}
+	t.CooldownUntil = now.Add(cooldown)
+}
@@ -0,0 +1,106 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestPool(tokens []string, opts PoolOptions) (*PoolTokenSource, *time.Time) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	p := NewPoolTokenSource(tokens, opts)
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func TestPoolTokenSource_Cooldown(t *testing.T) {
+	ctx := context.Background()
+	p, now := newTestPool([]string{"token-1", "token-2"}, PoolOptions{Cooldown: time.Minute})
+
+	var got []string
+	for range 3 {
+		token, err := p.Token(ctx)
+		if err != nil {
+			t.Fatalf("failed to get token: %v", err)
+		}
+		got = append(got, token)
+	}
+	if got[0] != "token-1" || got[1] != "token-2" || got[2] != "token-1" {
+		t.Fatalf("got tokens %v, want them in turn", got)
+	}
+
+	p.MarkLimited(ctx, "token-1")
+	p.TokenRejected(ctx, "token-2", TokenRejection{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Minute})
+	if _, err := p.Token(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, want %v", err, ErrRateLimited)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	for range 2 {
+		if token, err := p.Token(ctx); err != nil || token != "token-1" {
+			t.Fatalf("got %q, %v, want token-1 alone back from its cooldown", token, err)
+		}
+	}
+	*now = now.Add(5 * time.Minute)
+	if token, _ := p.Token(ctx); token != "token-2" {
+		t.Fatalf("got %q, want token-2 back after its Retry-After", token)
+	}
+}
+
+func TestPoolTokenSource_DropsUnauthorized(t *testing.T) {
+	ctx := context.Background()
+	p, now := newTestPool([]string{"token-1", "token-2"}, PoolOptions{
+		Strategy:        PoolLeastRecentlyUsed,
+		Cooldown:        time.Second,
+		MaxUnauthorized: 2,
+	})
+	unauthorized := TokenRejection{StatusCode: http.StatusUnauthorized}
+
+	p.TokenRejected(ctx, "token-1", unauthorized)
+	p.TokenAccepted(ctx, "token-1")
+	p.TokenRejected(ctx, "token-1", unauthorized)
+	if stats := p.Stats(); stats[0].Dropped || stats[0].Accepted != 1 {
+		t.Fatalf("got stats %+v, want token-1 kept after being accepted in between", stats[0])
+	}
+	p.TokenRejected(ctx, "token-1", unauthorized)
+
+	*now = now.Add(time.Minute)
+	for range 3 {
+		if token, err := p.Token(ctx); err != nil || token != "token-2" {
+			t.Fatalf("got %q, %v, want token-2 alone", token, err)
+		}
+	}
+	if stats := p.Stats(); !stats[0].Dropped || stats[1].Uses != 3 {
+		t.Fatalf("got stats %+v", stats)
+	}
+
+	for range 2 {
+		p.TokenRejected(ctx, "token-2", unauthorized)
+	}
+	if _, err := p.Token(ctx); !errors.Is(err, ErrNoTokens) {
+		t.Fatalf("got error %v, want %v", err, ErrNoTokens)
+	}
+}
+
+// The client must hand the status and Retry-After of a rejection to the pool.
+func TestMakeRequest_PoolTokenSourceFeedback(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{
+		{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"120"}}},
+		{status: http.StatusOK, body: playerBody},
+	}}
+	p, now := newTestPool([]string{"token-1", "token-2"}, PoolOptions{})
+	c := newTestClient(t, tr, WithTokenSource(p))
+
+	if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	stats := p.Stats()
+	if stats[0].Limited != 1 || !stats[0].CooldownUntil.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("got stats %+v, want token-1 cooling down for two minutes", stats[0])
+	}
+	if stats[1].Accepted != 1 {
+		t.Fatalf("got stats %+v, want token-2 accepted", stats[1])
+	}
+}
@@ -108,8 +108,9 @@ func (c *client) CreatePromoCode(ctx context.Context, in CreatePromoCodeInput) e
 		return err
 	}
 
-	_, err = makeRequest[any](
+	_, err = makeMutatingRequest[any](
 		ctx,
+		"CreatePromoCode",
 		http.MethodPost,
 		"/Reference/SavePromoCodeWithItemsAsync",
 		body,
@@ -130,17 +131,28 @@ type PromoCode struct {
 }
 
 type ListPromoCodesInput struct {
-	Code string
+	Code   string
+	Limit  int
+	Offset int
 }
 
 type listPromoCodesPayload struct {
-	Code string `json:"Code"`
+	Code      string `json:"Code"`
+	MaxRows   *int   `json:"MaxRows,omitempty"`
+	SkeepRows *int   `json:"SkeepRows,omitempty"`
 }
 
 func (in ListPromoCodesInput) toPayload() (*listPromoCodesPayload, error) {
-	return &listPromoCodesPayload{
+	p := &listPromoCodesPayload{
 		Code: in.Code,
-	}, nil
+	}
+	if in.Limit > 0 {
+		p.MaxRows = &in.Limit
+	}
+	if in.Offset > 0 {
+		p.SkeepRows = &in.Offset
+	}
+	return p, nil
 }
 
 func (c *client) ListPromoCodes(ctx context.Context, in ListPromoCodesInput) ([]PromoCode, error) {
@@ -172,6 +184,7 @@ func (c *client) ListPromoCodes(ctx context.Context, in ListPromoCodesInput) ([]
 
 	resp, err := makeRequest[response](
 		ctx,
+		"ListPromoCodes",
 		http.MethodPost,
 		"/Reference/GetPromoCodesPagingAsync",
 		body,
@@ -271,6 +284,7 @@ func (c *client) ListPromoCodeUsages(ctx context.Context, in ListPromoCodeUsages
 
 	resp, err := makeRequest[[]*usage](
 		ctx,
+		"ListPromoCodeUsages",
 		http.MethodPost,
 		"/Report/GetClientPromoCodes",
 		body,
@@ -0,0 +1,27 @@
+package backoffice
+
+import "github.com/extrasoftorg/betconstruct/internal/ratelimit"
+
+// RateLimit caps the request rate to the paths under Prefix, separately for
+// every token, or for every account when the token source implements
+// TokenIdentity. A trailing * is ignored, so "/Report/*" and "/Report/" are
+// the same, and "" matches every path.
+type RateLimit = ratelimit.Limit
+
+// WithRateLimit makes requests wait for capacity instead of running into 429s.
+// A request counts against the limit with the longest matching prefix. When
+// the wait would outlast the context deadline, the request fails right away
+// with context.DeadlineExceeded.
+func WithRateLimit(limits ...RateLimit) Option {
+	return func(c *client) {
+		c.limiter = ratelimit.New(limits)
+	}
+}
+
+// rateLimitKey returns what token's requests are limited under.
+func (c *client) rateLimitKey(token string) string {
+	if id, ok := c.ts.(TokenIdentity); ok {
+		return id.TokenIdentity(token)
+	}
+	return token
+}
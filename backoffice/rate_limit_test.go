@@ -0,0 +1,65 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{{status: http.StatusOK, body: playerBody}}}
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithRateLimit(
+		RateLimit{Prefix: "/Client/*", Rate: 20, Burst: 1},
+		RateLimit{Prefix: "/Reference/*", Rate: 0},
+	))
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		if _, err := c.GetPlayer(ctx, 42); err != nil {
+			t.Fatalf("failed to get player: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("three calls at 20/s took %s, want at least 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	c = newTestClient(t, tr, WithAuthToken("token-1"), WithRateLimit(RateLimit{Rate: 1}))
+	if _, err := c.GetPlayer(ctx, 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	calls := len(tr.calls())
+	if _, err := c.GetPlayer(ctx, 42); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(tr.calls()) != calls {
+		t.Fatal("sent a request the deadline left no room for")
+	}
+}
+
+// A token from a new login of the same account must not start over with a
+// full burst.
+func TestWithRateLimit_AcrossRefresh(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{{status: http.StatusOK, body: playerBody}}}
+	var logins atomic.Int32
+	ts := NewRefreshingTokenSource(newTestLogin(&logins, time.Hour), RefreshOptions{})
+	c := newTestClient(t, tr, WithTokenSource(ts), WithRateLimit(RateLimit{Rate: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetPlayer(ctx, 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	ts.MarkLimited(ctx, "token-1")
+	if _, err := c.GetPlayer(ctx, 42); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v for the refreshed token", err, context.DeadlineExceeded)
+	}
+	if calls := tr.calls(); len(calls) != 1 {
+		t.Fatalf("got calls %v, want only the first one", calls)
+	}
+}
@@ -52,7 +52,7 @@ func (c *client) ListPaymentMethods(ctx context.Context, req ListPaymentMethodsR
 		return nil, err
 	}
 
-	methods, err := makeRequest[[]*PaymentMethod](ctx, http.MethodPost, "/Reference/PaymentAPI", body, c)
+	methods, err := makeRequest[[]*PaymentMethod](ctx, "ListPaymentMethods", http.MethodPost, "/Reference/PaymentAPI", body, c)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +77,7 @@ func (c *client) FindPaymentMethodByName(ctx context.Context, name string) (*Pay
 		return nil, err
 	}
 
-	methods, err := makeRequest[[]PaymentMethod](ctx, http.MethodPost, "/Reference/PaymentAPI", body, c)
+	methods, err := makeRequest[[]PaymentMethod](ctx, "FindPaymentMethodByName", http.MethodPost, "/Reference/PaymentAPI", body, c)
 	if err != nil {
 		return nil, err
 	}
@@ -108,14 +108,14 @@ func (c *client) UpdatePaymentMethod(ctx context.Context, method PaymentMethod)
 		return err
 	}
 
-	_, err = makeRequest[any](ctx, http.MethodPost, "/Reference/PaymentAPI", body, c)
+	_, err = makeMutatingRequest[any](ctx, "UpdatePaymentMethod", http.MethodPost, "/Reference/PaymentAPI", body, c)
 	return err
 }
 
 type listPartnerDomainsResponse []PartnerDomain
 
 func (c *client) ListPartnerDomains(ctx context.Context, partnerID PartnerID) ([]PartnerDomain, error) {
-	domains, err := makeRequest[listPartnerDomainsResponse](ctx, http.MethodGet, "/Reference/GetPartnerDomains", nil, c)
+	domains, err := makeRequest[listPartnerDomainsResponse](ctx, "ListPartnerDomains", http.MethodGet, "/Reference/GetPartnerDomains", nil, c)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +134,6 @@ func (c *client) SetActiveDomain(ctx context.Context, domainID int32) error {
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](ctx, http.MethodPost, "/Reference/SetActiveDomain", body, c)
+	_, err = makeMutatingRequest[any](ctx, "SetActiveDomain", http.MethodPost, "/Reference/SetActiveDomain", body, c)
 	return err
 }
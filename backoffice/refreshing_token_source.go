@@ -0,0 +1,132 @@
+package backoffice
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/internal/singleflight"
+)
+
+const defaultRefreshSkew = time.Minute
+
+// LoginFunc logs in and returns a fresh token with its expiry, or the zero
+// time when the expiry isn't known.
+type LoginFunc func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+type RefreshOptions struct {
+	// Skew is how long before its expiry a token is replaced, so requests
+	// in flight don't race the expiry. It defaults to a minute.
+	Skew time.Duration
+	// LoginTimeout bounds each login. It defaults to 30s.
+	LoginTimeout time.Duration
+	// Backoff is the wait after a failed login before the next one; Token
+	// returns the failure until then. It doubles with each failure in a row
+	// up to a minute and defaults to a second.
+	Backoff time.Duration
+}
+
+// RefreshingTokenSource logs in on first use and again whenever the token is
+// about to expire or the backoffice rejects it with a 401 or 403. Concurrent
+// callers that need a new token share a single login, which runs detached
+// from their contexts so one caller giving up doesn't fail the rest. A 429
+// leaves the token alone, since a new one would be limited all the same.
+type RefreshingTokenSource struct {
+	login LoginFunc
+	opts  RefreshOptions
+	now   func() time.Time
+
+	refresh singleflight.Group[string]
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	stale     bool
+}
+
+func NewRefreshingTokenSource(login LoginFunc, opts RefreshOptions) *RefreshingTokenSource {
+	if opts.Skew <= 0 {
+		opts.Skew = defaultRefreshSkew
+	}
+	return &RefreshingTokenSource{
+		login:   login,
+		opts:    opts,
+		now:     time.Now,
+		refresh: singleflight.Group[string]{Timeout: opts.LoginTimeout, Backoff: opts.Backoff},
+	}
+}
+
+func (s *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	if s.usable() {
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+	s.mu.Unlock()
+
+	return s.refresh.Do(ctx, func(ctx context.Context) (string, error) {
+		// A login that finished since the check above already did the work.
+		s.mu.Lock()
+		if s.usable() {
+			token := s.token
+			s.mu.Unlock()
+			return token, nil
+		}
+		s.mu.Unlock()
+
+		token, expiresAt, err := s.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		s.mu.Lock()
+		s.token, s.expiresAt, s.stale = token, expiresAt, false
+		s.mu.Unlock()
+		return token, nil
+	})
+}
+
+// usable reports whether the current token can be handed out. s.mu must be
+// held.
+func (s *RefreshingTokenSource) usable() bool {
+	if s.token == "" || s.stale {
+		return false
+	}
+	return s.expiresAt.IsZero() || s.now().Before(s.expiresAt.Add(-s.opts.Skew))
+}
+
+// MarkLimited makes the next call to Token log in again when token is still
+// the current one.
+func (s *RefreshingTokenSource) MarkLimited(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.stale = true
+	}
+	return nil
+}
+
+func (s *RefreshingTokenSource) TokenRejected(ctx context.Context, token string, rejection TokenRejection) error {
+	if rejection.StatusCode == http.StatusTooManyRequests {
+		return nil
+	}
+	return s.MarkLimited(ctx, token)
+}
+
+func (s *RefreshingTokenSource) TokenAccepted(ctx context.Context, token string) error {
+	return nil
+}
+
+// TokenIdentity returns the same identity for every token, since they all
+// come from logins of the same operator.
+func (s *RefreshingTokenSource) TokenIdentity(token string) string {
+	return "refreshing"
+}
+
+var (
+	_ TokenSource   = &RefreshingTokenSource{}
+	_ TokenFeedback = &RefreshingTokenSource{}
+	_ TokenIdentity = &RefreshingTokenSource{}
+)
@@ -0,0 +1,120 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestLogin(logins *atomic.Int32, ttl time.Duration) LoginFunc {
+	return func(ctx context.Context) (string, time.Time, error) {
+		n := logins.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return fmt.Sprintf("token-%d", n), time.Now().Add(ttl), nil
+	}
+}
+
+func TestRefreshingTokenSource_ConcurrentLoginOnce(t *testing.T) {
+	var logins atomic.Int32
+	s := NewRefreshingTokenSource(newTestLogin(&logins, time.Hour), RefreshOptions{})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if token, err := s.Token(context.Background()); err != nil || token != "token-1" {
+				t.Errorf("got %q, %v, want token-1", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := logins.Load(); n != 1 {
+		t.Fatalf("logged in %d times, want 1", n)
+	}
+
+	s.TokenRejected(context.Background(), "token-1", TokenRejection{StatusCode: http.StatusTooManyRequests})
+	if token, _ := s.Token(context.Background()); token != "token-1" {
+		t.Fatalf("got %q, want token-1 kept after a 429", token)
+	}
+	s.TokenRejected(context.Background(), "token-1", TokenRejection{StatusCode: http.StatusUnauthorized})
+	if token, _ := s.Token(context.Background()); token != "token-2" {
+		t.Fatalf("got %q, want token-2 after a 401", token)
+	}
+}
+
+func TestRefreshingTokenSource_RefreshesBeforeExpiry(t *testing.T) {
+	var logins atomic.Int32
+	s := NewRefreshingTokenSource(newTestLogin(&logins, 10*time.Minute), RefreshOptions{Skew: time.Minute})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	if token, _ := s.Token(context.Background()); token != "token-1" {
+		t.Fatalf("got %q, want token-1", token)
+	}
+	now = now.Add(8 * time.Minute)
+	if token, _ := s.Token(context.Background()); token != "token-1" {
+		t.Fatalf("got %q, want token-1 two minutes before its expiry", token)
+	}
+	now = now.Add(90 * time.Second)
+	if token, _ := s.Token(context.Background()); token != "token-2" {
+		t.Fatalf("got %q, want token-2 within the skew", token)
+	}
+}
+
+// A caller that gives up must not fail the login the others wait for, and a
+// failed login must not be retried right away.
+func TestRefreshingTokenSource_LoginDetachedAndBackedOff(t *testing.T) {
+	var logins atomic.Int32
+	errDown := errors.New("auth service down")
+	fail := false
+	s := NewRefreshingTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		n := logins.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		if fail {
+			return "", time.Time{}, errDown
+		}
+		return fmt.Sprintf("token-%d", n), time.Now().Add(time.Hour), ctx.Err()
+	}, RefreshOptions{Backoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	go s.Token(ctx)
+	time.Sleep(time.Millisecond)
+	if token, err := s.Token(context.Background()); err != nil || token != "token-1" {
+		t.Fatalf("got %q, %v, want token-1 despite the first caller giving up", token, err)
+	}
+
+	fail = true
+	s.MarkLimited(context.Background(), "token-1")
+	for range 3 {
+		if _, err := s.Token(context.Background()); !errors.Is(err, errDown) {
+			t.Fatalf("got error %v, want %v", err, errDown)
+		}
+	}
+	if n := logins.Load(); n != 2 {
+		t.Fatalf("logged in %d times, want 2", n)
+	}
+}
+
+// An expired token must be replaced by logging in again, not rotated away.
+func TestMakeRequest_RefreshingTokenSource(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{
+		{status: http.StatusUnauthorized},
+		{status: http.StatusOK, body: playerBody},
+	}}
+	var logins atomic.Int32
+	c := newTestClient(t, tr, WithTokenSource(NewRefreshingTokenSource(newTestLogin(&logins, time.Hour), RefreshOptions{})))
+
+	if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	if calls := tr.calls(); len(calls) != 2 || calls[0] != "token-1" || calls[1] != "token-2" {
+		t.Fatalf("got calls %v, want token-1 then token-2", calls)
+	}
+}
@@ -49,6 +49,7 @@ func (c *client) GetBetHistory(ctx context.Context, req ListSportBetsRequest) (*
 	}
 	resp, err := makeRequest[getBetHistoryResponse](
 		ctx,
+		"GetBetHistory",
 		http.MethodPost,
 		"/Report/GetBetHistory",
 		body,
@@ -106,7 +107,7 @@ func (c *client) GetSportKindReport(ctx context.Context, req GetSportKindReportR
 	if err != nil {
 		return nil, err
 	}
-	results, err := makeRequest[[]SportKindReport](ctx, http.MethodPost, "/Report/GetSportKindReport", body, c)
+	results, err := makeRequest[[]SportKindReport](ctx, "GetSportKindReport", http.MethodPost, "/Report/GetSportKindReport", body, c)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +121,7 @@ func (c *client) ListSportBets(ctx context.Context, req ListSportBetsRequest) ([
 	}
 	bets, err := makeRequest[listSportBetsResponse](
 		ctx,
+		"ListSportBets",
 		http.MethodPost,
 		"/Report/GetBetHistory",
 		body,
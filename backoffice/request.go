@@ -4,14 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"io"
 	"net/http"
-)
 
-const (
-	baseURL = "https://backofficewebadmin.betconstruct.com/api/en"
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
+	"github.com/extrasoftorg/betconstruct/internal/middleware"
 )
 
 type response[T any] struct {
@@ -22,17 +19,18 @@ type response[T any] struct {
 
 func (c *client) attempt(
 	ctx context.Context,
-	method string,
-	path string,
-	body io.Reader,
+	call *Call,
 	token string,
 ) (*http.Response, error) {
-	fullURL := fmt.Sprintf("%s%s", baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
+	fullURL := c.endpoint + call.Path
+	req, err := http.NewRequestWithContext(ctx, call.Method, fullURL, bytes.NewReader(call.RequestBody))
 	if err != nil {
 		return nil, err
 	}
 
+	for key, values := range call.Header {
+		req.Header[key] = values
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authentication", token)
 
@@ -46,62 +44,186 @@ func (c *client) attempt(
 
 func makeRequest[T any](
 	ctx context.Context,
+	op string,
+	method string,
+	path string,
+	body []byte,
+	c *client,
+) (*T, error) {
+	return doRequest[T](ctx, op, method, path, body, c, false)
+}
+
+// makeMutatingRequest is makeRequest for calls that change state. They are only
+// retried after transient failures when the retry policy allows mutations.
+func makeMutatingRequest[T any](
+	ctx context.Context,
+	op string,
 	method string,
 	path string,
 	body []byte,
 	c *client,
 ) (*T, error) {
+	return doRequest[T](ctx, op, method, path, body, c, true)
+}
+
+func doRequest[T any](
+	ctx context.Context,
+	op string,
+	method string,
+	path string,
+	body []byte,
+	c *client,
+	mutating bool,
+) (*T, error) {
+	call := &Call{
+		Operation:   op,
+		Method:      method,
+		Path:        path,
+		RequestBody: body,
+		Header:      make(http.Header),
+	}
+
+	var data *T
+	base := DoerFunc(func(ctx context.Context, call *Call) Outcome {
+		var out Outcome
+		data, out = doAttempts[T](ctx, call, c, mutating)
+		return out
+	})
+	out := middleware.Chain(base, c.middleware).Do(ctx, call)
+	if out.Err != nil {
+		return nil, out.Err
+	}
+	if data == nil {
+		return nil, ErrNoResponse
+	}
+	return data, nil
+}
+
+// doAttempts sends call, rotating tokens and retrying as configured, and
+// decodes the response.
+func doAttempts[T any](ctx context.Context, call *Call, c *client, mutating bool) (*T, Outcome) {
 	var (
+		out        Outcome
 		lastToken  string
 		lastStatus int
+		lastBody   []byte
+		rejected   bool
+		rotations  int
+		retries    int
 	)
+	fail := func(err error) (*T, Outcome) {
+		out.Err = err
+		return nil, out
+	}
+	method, path := call.Method, call.Path
 
-	for attempt := range c.maxAttempts {
+	for rotations < c.maxAttempts {
 		token, err := c.ts.Token(ctx)
 		if err != nil {
-			return nil, err
+			return fail(err)
 		}
 
-		// If the token is the same as the last one, we can assume that the token source is not providing a new token and we should not retry.
-		if attempt > 0 && token == lastToken {
-			return nil, statusError(lastStatus)
+		// If the token is the same as the rejected one, we can assume that the token source is not providing a new token and we should not retry.
+		if rejected && token == lastToken {
+			return fail(newAPIError(method, path, lastStatus, lastBody, out.Attempts))
 		}
 		lastToken = token
+		rejected = false
 
-		resp, err := c.attempt(ctx, method, path, bytes.NewReader(body), token)
+		if err := c.limiter.Wait(ctx, c.rateLimitKey(token), path); err != nil {
+			return fail(err)
+		}
+		out.Attempts++
+		out.Token = token
+		resp, err := c.attempt(ctx, call, token)
 		if err != nil {
-			return nil, err
+			if c.retryPolicy.allows(retries, mutating) && isTransientError(ctx, err) {
+				if err := c.retryPolicy.wait(ctx, retries, 0); err != nil {
+					return fail(err)
+				}
+				retries++
+				continue
+			}
+			return fail(err)
 		}
+		out.StatusCode = resp.StatusCode
 
 		if isTokenRejection(resp.StatusCode) {
 			lastStatus = resp.StatusCode
-			drainAndClose(resp.Body)
-			_ = c.ts.MarkLimited(ctx, token)
+			lastBody = readErrorBody(resp.Body)
+			out.BodySize = int64(len(lastBody))
+			if fb, ok := c.ts.(TokenFeedback); ok {
+				_ = fb.TokenRejected(ctx, token, TokenRejection{
+					StatusCode: resp.StatusCode,
+					RetryAfter: parseRetryAfter(resp.Header),
+				})
+			} else {
+				_ = c.ts.MarkLimited(ctx, token)
+			}
+			rejected = true
+			rotations++
 			continue
 		}
+		if fb, ok := c.ts.(TokenFeedback); ok && statusError(resp.StatusCode) == nil {
+			_ = fb.TokenAccepted(ctx, token)
+		}
 
-		if err := statusError(resp.StatusCode); err != nil {
+		retryAfter := parseRetryAfter(resp.Header)
+		if isTransientStatus(resp.StatusCode) && c.retryPolicy.allows(retries, mutating) && c.retryPolicy.allowsWait(retryAfter) {
 			drainAndClose(resp.Body)
-			return nil, err
+			if err := c.retryPolicy.wait(ctx, retries, retryAfter); err != nil {
+				return fail(err)
+			}
+			retries++
+			continue
+		}
+
+		if statusError(resp.StatusCode) != nil {
+			errBody := readErrorBody(resp.Body)
+			out.BodySize = int64(len(errBody))
+			return fail(newAPIError(method, path, resp.StatusCode, errBody, out.Attempts))
 		}
 
-		return decode[T](resp)
+		data, size, err := decode[T](resp)
+		out.BodySize = size
+		if apiErr, ok := err.(*APIError); ok {
+			apiErr.Method = method
+			apiErr.Path = path
+			apiErr.Attempts = out.Attempts
+		}
+		if err != nil {
+			return fail(err)
+		}
+		return data, out
 	}
 
-	return nil, statusError(lastStatus)
+	return fail(newAPIError(method, path, lastStatus, lastBody, out.Attempts))
 }
 
-func decode[T any](resp *http.Response) (*T, error) {
+// decode reads the response envelope and returns its data along with the
+// size of the body.
+func decode[T any](resp *http.Response) (*T, int64, error) {
 	defer drainAndClose(resp.Body)
 
+	raw, err := io.ReadAll(resp.Body)
+	size := int64(len(raw))
+	if err != nil {
+		return nil, size, err
+	}
+
 	var data response[T]
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, size, err
 	} else if data.HasError {
-		return nil, errors.New(data.AlertMessage)
+		return nil, size, &APIError{
+			StatusCode:   resp.StatusCode,
+			HasError:     true,
+			AlertMessage: data.AlertMessage,
+			Body:         apierror.Truncate(raw),
+		}
 	}
 
-	return &data.Data, nil
+	return &data.Data, size, nil
 }
 
 // statusError maps an HTTP status code to a sentinel error. It returns nil for
@@ -139,6 +261,13 @@ func isTokenRejection(statusCode int) bool {
 	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests
 }
 
+// readErrorBody keeps the beginning of an error response for APIError and
+// discards the rest.
+func readErrorBody(body io.ReadCloser) []byte {
+	defer drainAndClose(body)
+	return apierror.ReadBody(body)
+}
+
 func drainAndClose(body io.ReadCloser) {
 	io.Copy(io.Discard, io.LimitReader(body, 64<<10))
 	body.Close()
@@ -8,11 +8,16 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
 )
 
 type stubResponse struct {
 	status int
 	body   string
+	header http.Header
+	err    error
 }
 
 // stubTransport serves canned responses in order and records the token used for
@@ -31,11 +36,19 @@ func (t *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	idx := min(len(t.tokens)-1, len(t.responses)-1)
 	r := t.responses[idx]
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	header := r.header
+	if header == nil {
+		header = make(http.Header)
+	}
 
 	return &http.Response{
 		StatusCode: r.status,
 		Body:       io.NopCloser(strings.NewReader(r.body)),
-		Header:     make(http.Header),
+		Header:     header,
 		Request:    req,
 	}, nil
 }
@@ -233,3 +246,239 @@ func TestNew_RequiresTokenSource(t *testing.T) {
 		t.Fatalf("got error %v, want %v", err, ErrMissingTokenSource)
 	}
 }
+
+// Failures must keep the request context around so callers can tell business
+// errors apart from outages.
+func TestMakeRequest_APIError(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		tr := &stubTransport{responses: []stubResponse{{status: http.StatusServiceUnavailable, body: "<html>down</html>"}}}
+		c := newTestClient(t, tr, WithAuthToken("token-1"))
+
+		_, err := c.GetPlayer(context.Background(), 42)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !errors.Is(err, ErrServiceUnavailable) {
+			t.Fatalf("got error %v, want %v", err, ErrServiceUnavailable)
+		}
+		if apiErr.Method != http.MethodGet || apiErr.Path != "/Client/GetClientById?id=42" {
+			t.Fatalf("got %s %s, want GET /Client/GetClientById?id=42", apiErr.Method, apiErr.Path)
+		}
+		if apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.HasError || apiErr.Attempts != 1 {
+			t.Fatalf("got %+v, want status 503, no HasError and 1 attempt", apiErr)
+		}
+		if apiErr.Body != "<html>down</html>" {
+			t.Fatalf("got body %q, want %q", apiErr.Body, "<html>down</html>")
+		}
+	})
+
+	t.Run("has error", func(t *testing.T) {
+		tr := &stubTransport{responses: []stubResponse{{status: http.StatusOK, body: `{"Data":null,"HasError":true,"AlertMessage":"Client not found"}`}}}
+		c := newTestClient(t, tr, WithAuthToken("token-1"))
+
+		_, err := c.GetPlayer(context.Background(), 42)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !apiErr.HasError || apiErr.AlertMessage != "Client not found" || apiErr.StatusCode != http.StatusOK {
+			t.Fatalf("got %+v, want HasError with alert message", apiErr)
+		}
+		if apiErr.Path != "/Client/GetClientById?id=42" || apiErr.Attempts != 1 {
+			t.Fatalf("got path %q and %d attempts, want request context", apiErr.Path, apiErr.Attempts)
+		}
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		tr := &stubTransport{responses: []stubResponse{{status: http.StatusBadGateway, body: strings.Repeat("x", 2*apierror.MaxBodyLen)}}}
+		c := newTestClient(t, tr, WithAuthToken("token-1"))
+
+		_, err := c.GetPlayer(context.Background(), 42)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if len(apiErr.Body) != apierror.MaxBodyLen+len("...") {
+			t.Fatalf("got body of %d bytes, want it truncated to %d", len(apiErr.Body), apierror.MaxBodyLen)
+		}
+	})
+}
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     2,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+// Transient failures are retried with the same token and do not count against
+// token rotation.
+func TestMakeRequest_RetriesTransientFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		first stubResponse
+	}{
+		{"bad gateway", stubResponse{status: http.StatusBadGateway}},
+		{"service unavailable", stubResponse{status: http.StatusServiceUnavailable}},
+		{"gateway timeout", stubResponse{status: http.StatusGatewayTimeout}},
+		{"dropped connection", stubResponse{err: io.ErrUnexpectedEOF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &stubTransport{responses: []stubResponse{tt.first, {status: http.StatusOK, body: playerBody}}}
+			c := newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(testRetryPolicy), WithMaxAttempts(1))
+
+			if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+				t.Fatalf("failed to get player: %v", err)
+			}
+			if calls := tr.calls(); len(calls) != 2 {
+				t.Fatalf("made %d requests, want 2", len(calls))
+			}
+		})
+	}
+}
+
+func TestMakeRequest_RetryBudgetExhausted(t *testing.T) {
+	tr := &stubTransport{responses: []stubResponse{{status: http.StatusServiceUnavailable}}}
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(testRetryPolicy))
+
+	_, err := c.GetPlayer(context.Background(), 42)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("got error %v, want %v", err, ErrServiceUnavailable)
+	}
+	if apiErr.Attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", apiErr.Attempts)
+	}
+	if calls := tr.calls(); len(calls) != 3 {
+		t.Fatalf("made %d requests, want 3", len(calls))
+	}
+}
+
+// Mutations may only be retried when the caller opts in.
+func TestMakeRequest_MutationRetryRequiresOptIn(t *testing.T) {
+	req := AddPaymentToPlayerRequest{PlayerID: 42, Amount: 10, Type: AddPaymentToPlayerRequestTypeCorrectionUp, Currency: "TRY"}
+	responses := []stubResponse{{status: http.StatusBadGateway}, {status: http.StatusOK, body: `{"Data":null,"HasError":false}`}}
+
+	tr := &stubTransport{responses: responses}
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(testRetryPolicy))
+	if err := c.AddPaymentToPlayer(context.Background(), req); !errors.Is(err, ErrBadGateway) {
+		t.Fatalf("got error %v, want %v", err, ErrBadGateway)
+	}
+	if calls := tr.calls(); len(calls) != 1 {
+		t.Fatalf("made %d requests, want 1", len(calls))
+	}
+
+	policy := testRetryPolicy
+	policy.RetryMutations = true
+	tr = &stubTransport{responses: responses}
+	c = newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(policy))
+	if err := c.AddPaymentToPlayer(context.Background(), req); err != nil {
+		t.Fatalf("failed to add payment: %v", err)
+	}
+	if calls := tr.calls(); len(calls) != 2 {
+		t.Fatalf("made %d requests, want 2", len(calls))
+	}
+}
+
+// Waiting for Retry-After must give up as soon as ctx is done.
+func TestMakeRequest_RetryRespectsContext(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"60"}}
+	tr := &stubTransport{responses: []stubResponse{{status: http.StatusServiceUnavailable, header: header}}}
+	policy := testRetryPolicy
+	policy.MaxBackoff = time.Minute
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.GetPlayer(ctx, 42); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if calls := tr.calls(); len(calls) != 1 {
+		t.Fatalf("made %d requests, want 1", len(calls))
+	}
+}
+
+// A Retry-After beyond MaxBackoff fails the call instead of parking it.
+func TestMakeRequest_RetryAfterBeyondMaxBackoff(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"86400"}}
+	tr := &stubTransport{responses: []stubResponse{{status: http.StatusServiceUnavailable, header: header}}}
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithRetryPolicy(testRetryPolicy))
+
+	start := time.Now()
+	if _, err := c.GetPlayer(context.Background(), 42); !errors.Is(err, ErrServiceUnavailable) {
+		t.Fatalf("got error %v, want %v", err, ErrServiceUnavailable)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("took %v, want the call to fail right away", d)
+	}
+	if calls := tr.calls(); len(calls) != 1 {
+		t.Fatalf("made %d requests, want 1", len(calls))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter(http.Header{"Retry-After": []string{"3"}}); d != 3*time.Second {
+		t.Fatalf("got %v, want 3s", d)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d := parseRetryAfter(http.Header{"Retry-After": []string{date}}); d <= 0 || d > time.Minute {
+		t.Fatalf("got %v, want up to a minute", d)
+	}
+	if d := parseRetryAfter(http.Header{}); d != 0 {
+		t.Fatalf("got %v, want 0", d)
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNew_BaseURL(t *testing.T) {
+	var got string
+	tr := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(playerBody)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	})
+	c := newTestClient(t, tr, WithAuthToken("token-1"), WithBaseURL("http://127.0.0.1:8080/"), WithLanguage("TR"))
+
+	if _, err := c.GetPlayer(context.Background(), 42); err != nil {
+		t.Fatalf("failed to get player: %v", err)
+	}
+	if want := "http://127.0.0.1:8080/api/tr/Client/GetClientById?id=42"; got != want {
+		t.Fatalf("requested %s, want %s", got, want)
+	}
+}
+
+func TestNew_ValidatesBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"no scheme", []Option{WithBaseURL("backoffice.local")}, ErrInvalidBaseURL},
+		{"ftp", []Option{WithBaseURL("ftp://backoffice.local")}, ErrInvalidBaseURL},
+		{"query", []Option{WithBaseURL("https://backoffice.local?x=1")}, ErrInvalidBaseURL},
+		{"empty language", []Option{WithLanguage("")}, ErrInvalidLanguage},
+		{"language with slash", []Option{WithLanguage("en/../x")}, ErrInvalidLanguage},
+		{"api segment", []Option{WithBaseURL("https://backoffice.local/api/en")}, ErrInvalidBaseURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithAuthToken("token-1")}, tt.opts...)
+			if _, err := New(opts...); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package backoffice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests are retried after transient failures:
+// 502, 503 and 504 responses, timeouts and dropped connections. Its budget is
+// separate from the token rotation controlled by WithMaxAttempts.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries on top of the first request. Zero
+	// disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It doubles on every
+	// retry up to MaxBackoff, and the actual delay is jittered between half
+	// and the full value. A Retry-After header takes precedence, but a
+	// response asking to wait longer than MaxBackoff is not retried.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryMutations allows retrying calls that change state, such as
+	// AddPaymentToPlayer. A retried mutation may be applied twice.
+	RetryMutations bool
+}
+
+const (
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+func (p RetryPolicy) allows(retries int, mutating bool) bool {
+	return retries < p.MaxRetries && (!mutating || p.RetryMutations)
+}
+
+// allowsWait reports whether the server's Retry-After fits within
+// MaxBackoff. Waiting longer would park the call for as long as the server
+// likes, so such responses fail right away instead.
+func (p RetryPolicy) allowsWait(retryAfter time.Duration) bool {
+	return retryAfter <= p.maxBackoff()
+}
+
+func (p RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+	return p.MaxBackoff
+}
+
+func (p RetryPolicy) backoff(retries int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	maxBackoff := p.maxBackoff()
+
+	d := initial
+	for range retries {
+		d *= 2
+		if d >= maxBackoff {
+			d = maxBackoff
+			break
+		}
+	}
+
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// wait sleeps before the next retry, preferring retryAfter when the server
+// sent one. Callers check allowsWait first. It returns early with the context error when ctx is done.
+func (p RetryPolicy) wait(ctx context.Context, retries int, retryAfter time.Duration) error {
+	d := retryAfter
+	if d <= 0 {
+		d = p.backoff(retries)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isTransientStatus(statusCode int) bool {
+	return statusCode == http.StatusBadGateway || statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout
+}
+
+// isTransientError reports whether a transport error is worth retrying. Errors
+// caused by ctx itself never are.
+func isTransientError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return isConnectionError(err) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date. It returns zero when the header is missing or malformed.
+func parseRetryAfter(header http.Header) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,13 @@
+//go:build !plan9
+
+package backoffice
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isConnectionError reports whether err is a reset or refused connection.
+func isConnectionError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
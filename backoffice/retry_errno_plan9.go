@@ -0,0 +1,7 @@
+package backoffice
+
+// isConnectionError reports false: plan9 has no errno for reset or refused
+// connections, so only timeouts and dropped responses are retried there.
+func isConnectionError(err error) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+//go:build !plan9
+
+package backoffice
+
+import (
+	"context"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestIsTransientError_Connection(t *testing.T) {
+	for _, errno := range []syscall.Errno{syscall.ECONNRESET, syscall.ECONNREFUSED} {
+		err := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", errno)}
+		if !isTransientError(context.Background(), err) {
+			t.Fatalf("got %v not transient, want transient", err)
+		}
+	}
+}
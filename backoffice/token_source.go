@@ -1,12 +1,39 @@
 package backoffice
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type TokenSource interface {
 	Token(ctx context.Context) (string, error)
 	MarkLimited(ctx context.Context, token string) error
 }
 
+// TokenFeedback is implemented by token sources that want to know more than
+// MarkLimited tells them. When the source implements it, the client calls
+// TokenRejected instead of MarkLimited and TokenAccepted after every
+// successful response.
+type TokenFeedback interface {
+	TokenRejected(ctx context.Context, token string, rejection TokenRejection) error
+	TokenAccepted(ctx context.Context, token string) error
+}
+
+// TokenIdentity is implemented by token sources whose tokens are successive
+// logins of one account. Tokens with the same identity share a rate limit,
+// so a fresh login doesn't start over with a full burst.
+type TokenIdentity interface {
+	TokenIdentity(token string) string
+}
+
+// TokenRejection describes why the backoffice turned a token away.
+type TokenRejection struct {
+	// StatusCode is 401, 403 or 429.
+	StatusCode int
+	// RetryAfter is the wait the backoffice asked for, or zero.
+	RetryAfter time.Duration
+}
+
 type staticTokenSource struct {
 	token string
 }
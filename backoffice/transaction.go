@@ -62,6 +62,7 @@ func (c *client) ListPlayerTransactions(ctx context.Context, req ListPlayerTrans
 	}
 	transactions, err := makeRequest[listPlayerTransactionsResponse](
 		ctx,
+		"ListPlayerTransactions",
 		http.MethodPost,
 		"/Client/GetClientTransactionsV1",
 		body,
@@ -87,6 +88,7 @@ func (c *client) ListPlayerCasinoGames(ctx context.Context, req ListPlayerCasino
 	}
 	resp, err := makeRequest[[]PlayerCasinoGame](
 		ctx,
+		"ListPlayerCasinoGames",
 		http.MethodPost,
 		"/Client/GetClientCasinoGames",
 		body,
@@ -69,12 +69,35 @@ const (
 )
 
 type Transaction struct {
-	ID        int64           `json:"Id"`
+	ID int64 `json:"Id"`
+	// Deprecated: Amount loses precision; use Money.
 	Amount    float64         `json:"Amount"`
 	PlayerID  PlayerID        `json:"ClientId"`
 	Type      TransactionType `json:"TypeId"`
 	Note      *string         `json:"Note"`
 	CreatedAt DateTime        `json:"CreatedLocal"`
+	// Money is the exact amount and currency.
+	Money Money `json:"Money"`
+}
+
+// UnmarshalJSON reads the backoffice's Amount and CurrencyId into Money, unless
+// the input already carries a Money object.
+func (t *Transaction) UnmarshalJSON(b []byte) error {
+	type plain Transaction
+	var w struct {
+		plain
+		Amount   Decimal `json:"Amount"`
+		Currency string  `json:"CurrencyId"`
+	}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	*t = Transaction(w.plain)
+	t.Amount = w.Amount.Float64()
+	if t.Money == (Money{}) {
+		t.Money = NewMoney(w.Amount, w.Currency)
+	}
+	return nil
 }
 
 type WithdrawalStatus string
@@ -83,7 +106,16 @@ func (w WithdrawalStatus) String() string {
 	return string(w)
 }
 
+// UnmarshalJSON reads the backoffice's numeric state code. It also accepts the
+// status names WithdrawalStatus encodes to, so a marshalled Withdrawal can be
+// read back.
 func (w *WithdrawalStatus) UnmarshalJSON(b []byte) error {
+	var name string
+	if json.Unmarshal(b, &name) == nil {
+		*w = WithdrawalStatus(name)
+		return nil
+	}
+
 	var id int
 	if err := json.Unmarshal(b, &id); err != nil {
 		return err
@@ -112,14 +144,38 @@ const (
 )
 
 type Withdrawal struct {
-	ID            int64            `json:"Id"`
-	Amount        float64          `json:"Amount"`
-	PlayerID      PlayerID         `json:"ClientId"`
-	RequestedAt   DateTime         `json:"RequestTimeLocal"`
-	PaymentMethod string           `json:"PaymentSystemName"`
-	AllowedAt     *DateTime        `json:"AllowTimeLocal"`
-	Info          string           `json:"Info"`
-	Status        WithdrawalStatus `json:"State"`
+	ID int64 `json:"Id"`
+	// Deprecated: Amount loses precision; use Money.
+	Amount          float64          `json:"Amount"`
+	PlayerID        PlayerID         `json:"ClientId"`
+	RequestedAt     DateTime         `json:"RequestTimeLocal"`
+	PaymentMethod   string           `json:"PaymentSystemName"`
+	PaymentSystemID int32            `json:"PaymentSystemId"`
+	AllowedAt       *DateTime        `json:"AllowTimeLocal"`
+	Info            string           `json:"Info"`
+	Status          WithdrawalStatus `json:"State"`
+	// Money is the exact amount and currency.
+	Money Money `json:"Money"`
+}
+
+// UnmarshalJSON reads the backoffice's Amount and CurrencyId into Money, unless
+// the input already carries a Money object.
+func (w *Withdrawal) UnmarshalJSON(b []byte) error {
+	type plain Withdrawal
+	var v struct {
+		plain
+		Amount   Decimal `json:"Amount"`
+		Currency string  `json:"CurrencyId"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*w = Withdrawal(v.plain)
+	w.Amount = v.Amount.Float64()
+	if w.Money == (Money{}) {
+		w.Money = NewMoney(v.Amount, v.Currency)
+	}
+	return nil
 }
 
 type RegisteredPlayer struct {
@@ -3,46 +3,182 @@ package backoffice
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
 
 type ListWithdrawalsRequest struct {
-	FromDate time.Time `json:"FromDateLocal"`
-	ToDate   time.Time `json:"ToDateLocal"`
-	ID       int64     `json:"Id"`
+	FromDate        time.Time          `json:"FromDateLocal"`
+	ToDate          time.Time          `json:"ToDateLocal"`
+	ID              int64              `json:"Id"`
+	PlayerID        PlayerID           `json:"ClientId"`
+	PaymentSystemID int32              `json:"PaymentSystemId"`
+	Statuses        []WithdrawalStatus `json:"StateList"`
+	// MinAmount and MaxAmount bound the requested amount. Zero leaves the
+	// bound open.
+	//
+	// Deprecated: set MinMoney and MaxMoney, which are sent exactly.
+	MinAmount float64 `json:"AmountFrom"`
+	MaxAmount float64 `json:"AmountTo"`
+	// MinMoney and MaxMoney, when not zero, are sent instead of MinAmount and
+	// MaxAmount.
+	MinMoney Decimal `json:"MinMoney"`
+	MaxMoney Decimal `json:"MaxMoney"`
+	Limit    int     `json:"MaxRows"`
+	Offset   int     `json:"SkeepRows"`
 }
 
-func (r *ListWithdrawalsRequest) MarshalJSON() ([]byte, error) {
-	type wire struct {
-		FromDate *time.Time `json:"FromDateLocal"`
-		ToDate   *time.Time `json:"ToDateLocal"`
-		ID       *int64     `json:"Id"`
+type listWithdrawalsPayload struct {
+	FromDate        *string                `json:"FromDateLocal"`
+	ToDate          *string                `json:"ToDateLocal"`
+	ID              *int64                 `json:"Id"`
+	PlayerID        *PlayerID              `json:"ClientId"`
+	PaymentSystemID *int32                 `json:"PaymentSystemId"`
+	States          []withdrawalStatusCode `json:"StateList,omitempty"`
+	MinAmount       *Decimal               `json:"AmountFrom"`
+	MaxAmount       *Decimal               `json:"AmountTo"`
+	MaxRows         *int                   `json:"MaxRows,omitempty"`
+	SkeepRows       *int                   `json:"SkeepRows,omitempty"`
+}
+
+// withdrawalStatusCode is a WithdrawalStatus as the backoffice's request
+// filters spell it.
+type withdrawalStatusCode WithdrawalStatus
+
+var withdrawalStatusCodes = map[WithdrawalStatus]int{
+	WithdrawalStatusPending:   0,
+	WithdrawalStatusPaid:      3,
+	WithdrawalStatusRejected:  -2,
+	WithdrawalStatusCancelled: -1,
+}
+
+func (w withdrawalStatusCode) MarshalJSON() ([]byte, error) {
+	id, ok := withdrawalStatusCodes[WithdrawalStatus(w)]
+	if !ok {
+		return nil, fmt.Errorf("unknown withdrawal status: %s", string(w))
+	}
+	return json.Marshal(id)
+}
+
+// amountBound picks the exact bound over the deprecated float one. It returns
+// nil for an open bound.
+func amountBound(exact Decimal, f float64) (*Decimal, error) {
+	if !exact.IsZero() {
+		return &exact, nil
+	}
+	if f == 0 {
+		return nil, nil
+	}
+	d, err := DecimalFromFloat(f)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r ListWithdrawalsRequest) wire(loc *time.Location) (listWithdrawalsPayload, error) {
+	var p listWithdrawalsPayload
+	for _, status := range r.Statuses {
+		p.States = append(p.States, withdrawalStatusCode(status))
 	}
-	w := wire{}
 	if !r.FromDate.IsZero() {
-		w.FromDate = &r.FromDate
+		fromDate := r.FromDate.In(loc).Format("02-01-06 - 15:04:05")
+		p.FromDate = &fromDate
 	}
 	if !r.ToDate.IsZero() {
-		w.ToDate = &r.ToDate
+		toDate := r.ToDate.In(loc).Format("02-01-06 - 15:04:05")
+		p.ToDate = &toDate
 	}
 	if r.ID != 0 {
-		w.ID = &r.ID
+		p.ID = &r.ID
+	}
+	if r.PlayerID != 0 {
+		p.PlayerID = &r.PlayerID
+	}
+	if r.PaymentSystemID != 0 {
+		p.PaymentSystemID = &r.PaymentSystemID
+	}
+	var err error
+	if p.MinAmount, err = amountBound(r.MinMoney, r.MinAmount); err != nil {
+		return p, err
+	}
+	if p.MaxAmount, err = amountBound(r.MaxMoney, r.MaxAmount); err != nil {
+		return p, err
+	}
+	if r.Limit > 0 {
+		p.MaxRows = &r.Limit
 	}
-	return json.Marshal(w)
+	if r.Offset > 0 {
+		p.SkeepRows = &r.Offset
+	}
+	return p, nil
+}
+
+// WithdrawalTotals summarizes every withdrawal matching the request, not just
+// the returned page.
+type WithdrawalTotals struct {
+	Count int `json:"Count"`
+	// Deprecated: Amount loses precision; use Money.
+	Amount float64 `json:"Amount"`
+	// Money is the exact total. The backoffice does not report its currency.
+	Money Money `json:"Money"`
+}
+
+// UnmarshalJSON reads the backoffice's Amount into Money, unless the input
+// already carries a Money object.
+func (t *WithdrawalTotals) UnmarshalJSON(b []byte) error {
+	type plain WithdrawalTotals
+	var v struct {
+		plain
+		Amount Decimal `json:"Amount"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*t = WithdrawalTotals(v.plain)
+	t.Amount = v.Amount.Float64()
+	if t.Money == (Money{}) {
+		t.Money = NewMoney(v.Amount, "")
+	}
+	return nil
+}
+
+type ListWithdrawalsOutput struct {
+	Withdrawals []Withdrawal
+	Totals      WithdrawalTotals
 }
 
 type listWithdrawalsResponse struct {
-	Withdrawals []Withdrawal `json:"ClientRequests"`
+	Withdrawals []Withdrawal     `json:"ClientRequests"`
+	Totals      WithdrawalTotals `json:"Totals"`
 }
 
 func (c *client) ListWithdrawals(ctx context.Context, req ListWithdrawalsRequest) ([]Withdrawal, error) {
-	body, err := req.MarshalJSON()
+	out, err := c.listWithdrawals(ctx, "ListWithdrawals", req)
+	if err != nil {
+		return nil, err
+	}
+	return out.Withdrawals, nil
+}
+
+func (c *client) ListWithdrawalsWithTotals(ctx context.Context, req ListWithdrawalsRequest) (*ListWithdrawalsOutput, error) {
+	return c.listWithdrawals(ctx, "ListWithdrawalsWithTotals", req)
+}
+
+// listWithdrawals lists withdrawals as part of the client call op.
+func (c *client) listWithdrawals(ctx context.Context, op string, req ListWithdrawalsRequest) (*ListWithdrawalsOutput, error) {
+	p, err := req.wire(c.timeLocation)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(p)
 	if err != nil {
 		return nil, err
 	}
-	withdrawals, err := makeRequest[listWithdrawalsResponse](
+	resp, err := makeRequest[listWithdrawalsResponse](
 		ctx,
+		op,
 		http.MethodPost,
 		"/Client/GetClientWithdrawalRequestsWithTotals",
 		body,
@@ -51,5 +187,8 @@ func (c *client) ListWithdrawals(ctx context.Context, req ListWithdrawalsRequest
 	if err != nil {
 		return nil, err
 	}
-	return withdrawals.Withdrawals, nil
+	return &ListWithdrawalsOutput{
+		Withdrawals: resp.Withdrawals,
+		Totals:      resp.Totals,
+	}, nil
 }
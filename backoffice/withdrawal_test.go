@@ -0,0 +1,60 @@
+package backoffice
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// A marshalled withdrawal status must read back, including statuses the client
+// does not know.
+func TestWithdrawalStatus_JSONRoundTrip(t *testing.T) {
+	for _, status := range []WithdrawalStatus{"", WithdrawalStatusPaid, WithdrawalStatusUnknown} {
+		b, err := json.Marshal(Withdrawal{ID: 1, Status: status})
+		if err != nil {
+			t.Fatalf("failed to marshal status %q: %v", status, err)
+		}
+		var got struct {
+			Status WithdrawalStatus `json:"State"`
+		}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", b, err)
+		}
+		if got.Status != status {
+			t.Fatalf("got status %q, want %q", got.Status, status)
+		}
+	}
+
+	var status WithdrawalStatus
+	if err := json.Unmarshal([]byte(`-2`), &status); err != nil || status != WithdrawalStatusRejected {
+		t.Fatalf("got %q, %v, want rejected from the wire code", status, err)
+	}
+}
+
+// A marshalled request reads back unchanged, times included.
+func TestListWithdrawalsRequest_JSONRoundTrip(t *testing.T) {
+	req := ListWithdrawalsRequest{
+		FromDate: time.Date(2025, 3, 1, 12, 0, 0, 0, TimeZone),
+		ToDate:   time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC),
+		PlayerID: 42,
+		Statuses: []WithdrawalStatus{WithdrawalStatusPending, WithdrawalStatusPaid},
+		MinMoney: NewDecimal(1050, 2),
+		Limit:    20,
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var got ListWithdrawalsRequest
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", b, err)
+	}
+	if !got.FromDate.Equal(req.FromDate) || !got.ToDate.Equal(req.ToDate) {
+		t.Fatalf("got dates %v and %v, want %v and %v", got.FromDate, got.ToDate, req.FromDate, req.ToDate)
+	}
+	if got.PlayerID != req.PlayerID || !slices.Equal(got.Statuses, req.Statuses) || !got.MinMoney.Equal(req.MinMoney) || got.Limit != req.Limit {
+		t.Fatalf("got %+v, want %+v", got, req)
+	}
+}
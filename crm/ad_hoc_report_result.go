@@ -1,16 +1,60 @@
 package crm
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
 )
 
 var ErrReportResultNotReady = errors.New("report result not ready")
 
+type ReportFormat string
+
+const (
+	ReportFormatXLSX ReportFormat = "xlsx"
+	ReportFormatCSV  ReportFormat = "csv"
+)
+
+// maxEnvelopeLen bounds how much of a JSON answer to a download is read to
+// tell why there is no file.
+const maxEnvelopeLen = 1 << 20
+
+type DownloadOptions struct {
+	// Format defaults to xlsx.
+	Format ReportFormat
+	// Currency amounts are converted to. It defaults to TRY.
+	Currency string
+	// Location is the time zone dates are written in. It defaults to UTC+3
+	// and its offset must be a whole number of hours.
+	Location *time.Location
+	// FileName defaults to report.
+	FileName string
+}
+
+func (o DownloadOptions) withDefaults() DownloadOptions {
+	if o.Format == "" {
+		o.Format = ReportFormatXLSX
+	}
+	if o.Currency == "" {
+		o.Currency = defaultReportCurrency
+	}
+	if o.Location == nil {
+		o.Location = defaultReportLocation
+	}
+	if o.FileName == "" {
+		o.FileName = "report"
+	}
+	return o
+}
+
 type downloadReportAsExcelRequest struct {
 	ReportResultID int32  `json:"ReportResultId"`
 	Currency       string `json:"CurrencyCode"`
@@ -20,34 +64,75 @@ type downloadReportAsExcelRequest struct {
 	FileName       string `json:"fileName"`
 }
 
-func (c *client) DownloadReportAsExcel(ctx context.Context, reportResultID int32) ([]byte, error) {
-	req := downloadReportAsExcelRequest{
+// DownloadReport streams a report result to w. When the result isn't ready
+// yet it returns ErrReportResultNotReady without writing anything; a
+// connection lost halfway may leave w with part of the file.
+func (c *client) DownloadReport(ctx context.Context, reportResultID int32, w io.Writer, opts DownloadOptions) error {
+	opts = opts.withDefaults()
+	if opts.Format != ReportFormatXLSX && opts.Format != ReportFormatCSV {
+		return fmt.Errorf("unsupported report format: %s", opts.Format)
+	}
+	_, offset := time.Now().In(opts.Location).Zone()
+	if offset%3600 != 0 {
+		return fmt.Errorf("time zone %s is not a whole number of hours from UTC", opts.Location)
+	}
+
+	body, err := json.Marshal(downloadReportAsExcelRequest{
 		ReportResultID: reportResultID,
-		Currency:       "TRY",
-		DocumentType:   "xlsx",
+		Currency:       opts.Currency,
+		DocumentType:   string(opts.Format),
 		ReportType:     "0",
-		TZ:             3,
-		FileName:       "report",
-	}
-	body, err := json.Marshal(req)
+		TZ:             offset / 3600,
+		FileName:       opts.FileName,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var bts []byte
-	if _, err := makeRequest[[]byte](ctx, http.MethodPost, "/AdHocReportResult/GetExcel", bytes.NewReader(body), c, func(r io.Reader) error {
-		bts, err = io.ReadAll(r)
+	const path = "/AdHocReportResult/GetExcel"
+	_, err = makeRequest[any](ctx, "DownloadReport", http.MethodPost, path, bytes.NewReader(body), c, func(r io.Reader) error {
+		br := bufio.NewReader(r)
+		if first, err := br.Peek(1); err != nil || first[0] != '{' {
+			_, err := io.Copy(w, br)
+			return err
+		}
+
+		// Files never start with a brace; a JSON envelope says why there is
+		// no file.
+		raw, err := io.ReadAll(io.LimitReader(br, maxEnvelopeLen))
 		if err != nil {
 			return err
 		}
 		var data response[any]
-		if err := json.Unmarshal(bts, &data); err == nil {
-			return ErrReportResultNotReady
+		if err := json.Unmarshal(raw, &data); err != nil {
+			_, err := io.Copy(w, io.MultiReader(bytes.NewReader(raw), br))
+			return err
 		}
-		return nil
-	}); err != nil {
+		if data.HasError {
+			return &APIError{
+				Method:       http.MethodPost,
+				Path:         path,
+				StatusCode:   http.StatusOK,
+				HasError:     true,
+				AlertMessage: data.AlertMessage,
+				Body:         apierror.Truncate(raw),
+				Attempts:     1,
+			}
+		}
+		return ErrReportResultNotReady
+	})
+	return err
+}
+
+// DownloadReportAsExcel downloads a report result as an xlsx workbook in
+// TRY and UTC+3.
+//
+// Deprecated: use DownloadReport, which streams the file and takes the
+// format, currency and time zone.
+func (c *client) DownloadReportAsExcel(ctx context.Context, reportResultID int32) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.DownloadReport(ctx, reportResultID, &buf, DownloadOptions{}); err != nil {
 		return nil, err
 	}
-
-	return bts, nil
+	return buf.Bytes(), nil
 }
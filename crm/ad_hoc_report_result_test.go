@@ -0,0 +1,70 @@
+package crm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDownloadReport(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		switch len(requests) {
+		case 1:
+			fmt.Fprint(w, "PlayerId,Amount\n1001,250.50\n")
+		case 2:
+			fmt.Fprint(w, `{"HasError": false, "Data": null}`)
+		default:
+			fmt.Fprint(w, `{"HasError": true, "AlertMessage": "result deleted"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	err = c.DownloadReport(ctx, 3, &buf, DownloadOptions{
+		Format:   ReportFormatCSV,
+		Currency: "EUR",
+		Location: time.FixedZone("UTC+2", 2*60*60),
+	})
+	if err != nil {
+		t.Fatalf("failed to download report: %v", err)
+	}
+	if buf.String() != "PlayerId,Amount\n1001,250.50\n" {
+		t.Fatalf("got file %q", buf.String())
+	}
+	want := `{"ReportResultId":3,"CurrencyCode":"EUR","DocumentType":"csv","ReportType":"0","UserTimeZone":2,"fileName":"report"}`
+	if requests[0] != want {
+		t.Fatalf("got request %s, want %s", requests[0], want)
+	}
+
+	buf.Reset()
+	if err := c.DownloadReport(ctx, 3, &buf, DownloadOptions{}); !errors.Is(err, ErrReportResultNotReady) || buf.Len() > 0 {
+		t.Fatalf("got error %v and %d bytes, want %v and nothing written", err, buf.Len(), ErrReportResultNotReady)
+	}
+	var apiErr *APIError
+	if err := c.DownloadReport(ctx, 3, &buf, DownloadOptions{}); !errors.As(err, &apiErr) || apiErr.AlertMessage != "result deleted" {
+		t.Fatalf("got error %v, want the alert message", err)
+	}
+
+	india := time.FixedZone("UTC+5:30", 5*60*60+30*60)
+	if err := c.DownloadReport(ctx, 3, &buf, DownloadOptions{Location: india}); err == nil {
+		t.Fatal("got no error for a time zone the CRM can't express")
+	}
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+}
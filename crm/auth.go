@@ -8,25 +8,27 @@ import (
 	"strings"
 )
 
+// Login exchanges the BetConstruct token for a new CRM auth token. Concurrent
+// calls, and refreshes triggered by expired tokens, share a single login.
+// Middleware sees the login call without its body or token.
 func (c *client) Login(ctx context.Context) error {
+	_, err := c.tokens.refresh(ctx, c.tokens.get())
+	return err
+}
+
+func (c *client) login(ctx context.Context) (string, error) {
 	body, err := json.Marshal(c.betconstructToken)
 	if err != nil {
-		return err
+		return "", err
 	}
-	authToken, err := makeRequest[string](ctx, http.MethodPost, "/User/LoginWithPlatform", bytes.NewReader(body), c, nil)
+	authToken, err := doRequest[string](ctx, "Login", http.MethodPost, "/User/LoginWithPlatform", bytes.NewReader(body), c, nil, false, true)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if authToken == nil {
-		return ErrUnauthorized
-	}
-
-	token := strings.TrimPrefix(*authToken, "Bearer ")
-	c.authToken = token
 
-	return nil
+	return strings.TrimPrefix(*authToken, "Bearer "), nil
 }
 
 func (c *client) AuthToken() string {
-	return c.authToken
+	return c.tokens.get()
 }
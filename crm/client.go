@@ -4,29 +4,52 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
+	"github.com/extrasoftorg/betconstruct/internal/ratelimit"
+)
+
+const (
+	defaultBaseURL  = "https://crm-t.betconstruct.com"
+	defaultLanguage = "en"
 )
 
 type client struct {
 	httpClient        *http.Client
-	authToken         string
+	baseURL           string
+	language          string
+	endpoint          string
+	tokens            tokenManager
 	betconstructToken string
 	refreshOnExpiry   bool
+	limiter           *ratelimit.Limiter
+	middleware        []Middleware
 }
 
 func New(ctx context.Context, opts ...Option) (Client, error) {
 	c := &client{
 		httpClient: http.DefaultClient,
+		baseURL:    defaultBaseURL,
+		language:   defaultLanguage,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.tokens.login = c.login
+
+	endpoint, err := endpoint.Join(c.baseURL, c.language)
+	if err != nil {
+		return nil, err
+	}
+	c.endpoint = endpoint
+
 	if c.betconstructToken != "" {
 		if err := c.Login(ctx); err != nil {
 			return nil, fmt.Errorf("failed to login: %w", err)
 		}
 	}
 
-	if c.authToken == "" {
+	if c.tokens.get() == "" {
 		return nil, fmt.Errorf("auth token is not set: %w", ErrUnauthorized)
 	}
 
@@ -35,9 +58,31 @@ func New(ctx context.Context, opts ...Option) (Client, error) {
 
 type Option func(c *client)
 
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL points the client at another CRM, such as a staging environment
+// or a local test server. The language segment is appended to it, so it must
+// not end in /api/en.
+func WithBaseURL(baseURL string) Option {
+	return func(c *client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithLanguage selects the language segment of the API path. It defaults to en.
+func WithLanguage(language string) Option {
+	return func(c *client) {
+		c.language = language
+	}
+}
+
 func WithAuthToken(authToken string) Option {
 	return func(c *client) {
-		c.authToken = authToken
+		c.tokens.set(authToken)
 	}
 }
 
@@ -47,8 +92,16 @@ func WithBetconstructToken(betconstructToken string) Option {
 	}
 }
 
+// WithRefreshOnExpiry logs in again with the BetConstruct token when the CRM
+// rejects the auth token, and ahead of time when the token is a JWT about to
+// expire.
 func WithRefreshOnExpiry() Option {
 	return func(c *client) {
 		c.refreshOnExpiry = true
 	}
 }
+
+// canRefresh reports whether an expired auth token can be replaced.
+func (c *client) canRefresh() bool {
+	return c.refreshOnExpiry && c.betconstructToken != ""
+}
@@ -1,6 +1,9 @@
 package crm
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type Client interface {
 	Login(ctx context.Context) error
@@ -9,9 +12,13 @@ type Client interface {
 	CreateReport(ctx context.Context, in CreateReportInput) (*CreateReportResponse, error)
 	ExecuteReport(ctx context.Context, reportID int32) error
 
-	ListReports(ctx context.Context, pageSize, pageNumber int) ([]Report, error)
+	ListReports(ctx context.Context, in ListReportsInput) ([]Report, error)
 
+	DownloadReport(ctx context.Context, reportResultID int32, w io.Writer, opts DownloadOptions) error
 	DownloadReportAsExcel(ctx context.Context, reportResultID int32) ([]byte, error)
 
 	ListReportResults(ctx context.Context, reportID int32) ([]ReportResult, error)
+	ListReportResultsPage(ctx context.Context, in ListReportResultsInput) ([]ReportResult, error)
+
+	RunReport(ctx context.Context, in CreateReportInput, opts RunOptions) (*RunResult, error)
 }
@@ -1,6 +1,11 @@
 package crm
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
+	"github.com/extrasoftorg/betconstruct/internal/endpoint"
+)
 
 var (
 	ErrBadRequest          = errors.New("bad request")
@@ -13,4 +18,17 @@ var (
 	ErrBadGateway          = errors.New("bad gateway")
 	ErrServiceUnavailable  = errors.New("service unavailable")
 	ErrUnexpectedStatus    = errors.New("unexpected status")
+
+	ErrInvalidBaseURL  = endpoint.ErrInvalidBaseURL
+	ErrInvalidLanguage = endpoint.ErrInvalidLanguage
 )
+
+// APIError describes a failed CRM call. Status failures wrap one of the
+// sentinels above, so errors.Is(err, ErrNotFound) keeps working.
+// Business failures, where the CRM answers with HasError set, carry the
+// AlertMessage instead.
+type APIError = apierror.Error
+
+func newAPIError(method, path string, statusCode int, body []byte, attempts int) *APIError {
+	return apierror.New(method, path, statusCode, body, attempts, statusError(statusCode))
+}
@@ -0,0 +1,135 @@
+// Command gencolumns generates the CRM report column catalog from a JSON
+// dump of the player table columns.
+//
+// Each entry of the dump looks like
+//
+//	{"name": "PlayerId", "id": 38, "displayName": "Player ID", "kind": "id", "ops": ["eq"]}
+//
+// where ops is optional and defaults to what the kind supports. Only add
+// columns whose IDs were captured from the CRM; a wrong ID silently selects
+// another column.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+type column struct {
+	Name        string   `json:"name"`
+	ID          int      `json:"id"`
+	DisplayName string   `json:"displayName"`
+	Kind        string   `json:"kind"`
+	Ops         []string `json:"ops"`
+}
+
+var kinds = map[string]string{
+	"string": "ReportColumnKindString",
+	"number": "ReportColumnKindNumber",
+	"id":     "ReportColumnKindID",
+	"amount": "ReportColumnKindAmount",
+	"date":   "ReportColumnKindDate",
+	"bool":   "ReportColumnKindBool",
+}
+
+var tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"goName": goName,
+	"kind":   func(k string) string { return kinds[k] },
+	"op":     func(op string) string { return "ReportFilterOp" + goName(strings.ReplaceAll(op, "_", " ")) },
+}).Parse(`// Code generated by gencolumns from {{.Source}}; DO NOT EDIT.
+
+package crm
+
+const (
+{{- range .Columns}}
+	ReportColumn{{goName .Name}} ReportColumn = {{printf "%q" .Name}}
+{{- end}}
+)
+
+var reportColumnCatalog = []ReportColumnInfo{
+{{- range .Columns}}
+	{
+		Column:      ReportColumn{{goName .Name}},
+		ID:          {{.ID}},
+		DisplayName: {{printf "%q" .DisplayName}},
+		Kind:        {{kind .Kind}},
+		{{- if .Ops}}
+		Ops: []ReportFilterOp{ {{- range $i, $op := .Ops}}{{if $i}}, {{end}}{{op $op}}{{end -}} },
+		{{- end}}
+	},
+{{- end}}
+}
+`))
+
+func main() {
+	in := flag.String("in", "report_columns.json", "column dump to read")
+	out := flag.String("out", "report_columns_gen.go", "Go file to write")
+	flag.Parse()
+
+	raw, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var columns []column
+	if err := json.Unmarshal(raw, &columns); err != nil {
+		log.Fatalf("failed to parse %s: %v", *in, err)
+	}
+	if err := validate(columns); err != nil {
+		log.Fatalf("invalid %s: %v", *in, err)
+	}
+	slices.SortFunc(columns, func(a, b column) int { return a.ID - b.ID })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{"Source": *in, "Columns": columns}); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func validate(columns []column) error {
+	names := make(map[string]bool, len(columns))
+	ids := make(map[int]bool, len(columns))
+	for _, c := range columns {
+		switch {
+		case c.Name == "" || c.ID <= 0:
+			return fmt.Errorf("column %+v needs a name and an id", c)
+		case names[c.Name]:
+			return fmt.Errorf("duplicate column name %s", c.Name)
+		case ids[c.ID]:
+			return fmt.Errorf("duplicate column id %d", c.ID)
+		case kinds[c.Kind] == "":
+			return fmt.Errorf("column %s has unknown kind %q", c.Name, c.Kind)
+		}
+		names[c.Name], ids[c.ID] = true, true
+	}
+	return nil
+}
+
+// goName turns a column name into the suffix of its Go identifier, spelling
+// Id as ID the way Go names do: "PlayerId" becomes "PlayerID".
+func goName(name string) string {
+	var words []string
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		words = append(words, strings.ToUpper(w[:1])+w[1:])
+	}
+	s := strings.Join(words, "")
+	if strings.HasSuffix(s, "Id") {
+		s = strings.TrimSuffix(s, "Id") + "ID"
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+package crm
+
+import "github.com/extrasoftorg/betconstruct/internal/middleware"
+
+var ErrNoResponse = middleware.ErrNoResponse
+
+type (
+	Call       = middleware.Call
+	Outcome    = middleware.Outcome
+	Doer       = middleware.Doer
+	DoerFunc   = middleware.DoerFunc
+	Middleware = middleware.Middleware
+)
+
+// WithMiddleware adds middleware around every call. The first middleware
+// added is the outermost. Login calls go through it too, with the request
+// body and token left out.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
@@ -0,0 +1,53 @@
+package crm
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var logins atomic.Int32
+	srv := newTestServer(t, &logins)
+	c := newTestClient(t, srv, "expired")
+
+	var ops []string
+	var calls []Call
+	var outcomes []Outcome
+	record := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, call *Call) Outcome {
+			out := next.Do(ctx, call)
+			ops, calls, outcomes = append(ops, call.Operation), append(calls, *call), append(outcomes, out)
+			return out
+		})
+	}
+	c.middleware = append(c.middleware, record)
+
+	if err := c.ExecuteReport(context.Background(), 7); err != nil {
+		t.Fatalf("failed to execute report: %v", err)
+	}
+	if len(ops) != 2 || ops[0] != "Login" || ops[1] != "ExecuteReport" {
+		t.Fatalf("got operations %v, want the refresh login inside ExecuteReport", ops)
+	}
+	// The login carries the BetConstruct token, so middleware must not see it.
+	if calls[0].RequestBody != nil || outcomes[0].Token != "" || outcomes[0].Err != nil {
+		t.Fatalf("got login call %+v with outcome %+v, want the body and token left out", calls[0], outcomes[0])
+	}
+	out := outcomes[1]
+	if out.Attempts != 2 || out.Token != "fresh-1" || out.StatusCode != http.StatusOK || out.BodySize == 0 {
+		t.Fatalf("got outcome %+v", out)
+	}
+
+	// Streamed downloads are counted as they are read.
+	c = newReportClient(t, &reportServer{}).(*client)
+	c.middleware = append(c.middleware, record)
+	var buf strings.Builder
+	if err := c.DownloadReport(context.Background(), 3, &buf, DownloadOptions{}); err != nil {
+		t.Fatalf("failed to download report: %v", err)
+	}
+	if last := outcomes[len(outcomes)-1]; last.BodySize != int64(buf.Len()) || ops[len(ops)-1] != "DownloadReport" {
+		t.Fatalf("got %s outcome %+v for %d bytes", ops[len(ops)-1], last, buf.Len())
+	}
+}
@@ -0,0 +1,32 @@
+package crm
+
+import (
+	"context"
+	"iter"
+)
+
+// AllReportResults iterates over every result matching in, page by page.
+// in.PageSize sets the page size and in.PageNumber where to start.
+func AllReportResults(ctx context.Context, c Client, in ListReportResultsInput) iter.Seq2[ReportResult, error] {
+	return func(yield func(ReportResult, error) bool) {
+		page := in
+		paging := newListPaging(page.PageSize, page.PageNumber)
+		page.PageSize, page.PageNumber = paging.PageSize, paging.PageNumber
+		for {
+			results, err := c.ListReportResultsPage(ctx, page)
+			if err != nil {
+				yield(ReportResult{}, err)
+				return
+			}
+			for _, r := range results {
+				if !yield(r, nil) {
+					return
+				}
+			}
+			if len(results) < page.PageSize {
+				return
+			}
+			page.PageNumber++
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package crm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"Data": null}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"), WithRateLimit(
+		RateLimit{Prefix: "/Report/*", Rate: 1},
+	))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.ExecuteReport(ctx, 7); err != nil {
+		t.Fatalf("failed to execute report: %v", err)
+	}
+	if err := c.ExecuteReport(ctx, 7); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := c.ListReportResults(ctx, 7); err != nil {
+		t.Fatalf("got error %v for a path outside the limit", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("got %d requests, want 2", n)
+	}
+}
+
+// A refreshed token must not start over with a full burst.
+func TestWithRateLimit_AcrossRefresh(t *testing.T) {
+	var logins atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/en/User/LoginWithPlatform" {
+			fmt.Fprintf(w, `{"Data": "token-%d"}`, logins.Add(1))
+			return
+		}
+		fmt.Fprint(w, `{"Data": null}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithBetconstructToken("bc"), WithRateLimit(
+		RateLimit{Prefix: "/Report/*", Rate: 1},
+	))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.ExecuteReport(ctx, 7); err != nil {
+		t.Fatalf("failed to execute report: %v", err)
+	}
+	if err := c.Login(ctx); err != nil || c.AuthToken() != "token-2" {
+		t.Fatalf("got token %q, %v, want token-2", c.AuthToken(), err)
+	}
+	if err := c.ExecuteReport(ctx, 7); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v for the refreshed token", err, context.DeadlineExceeded)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -20,46 +21,153 @@ func (c *client) ExecuteReport(ctx context.Context, reportID int32) error {
 	if err != nil {
 		return err
 	}
-	_, err = makeRequest[any](ctx, http.MethodPost, "/Report/Execute", bytes.NewReader(body), c, nil)
+	_, err = makeRequest[any](ctx, "ExecuteReport", http.MethodPost, "/Report/Execute", bytes.NewReader(body), c, nil)
 	return err
 }
 
 type Report struct {
-	Id          int32     `json:"ReportId"`
-	Name        string    `json:"Name"`
-	CreatorName string    `json:"CreatorName"`
-	State       int       `json:"State"`
-	ReportType  int       `json:"ReportType"`
-	CreatedAt   time.Time `json:"CreatedDate"`
-	HasResults  bool      `json:"HasResults"`
-	IsExported  bool      `json:"IsExported"`
+	Id          int32       `json:"ReportId"`
+	Name        string      `json:"Name"`
+	Description string      `json:"Description"`
+	CreatorName string      `json:"CreatorName"`
+	State       ReportState `json:"State"`
+	ReportType  ReportType  `json:"ReportType"`
+	CreatedAt   time.Time   `json:"CreatedDate"`
+	ArchivedAt  *time.Time  `json:"ArchivedDate"`
+	HasResults  bool        `json:"HasResults"`
+	IsExported  bool        `json:"IsExported"`
+}
+
+type ReportSortField string
+
+const (
+	ReportSortFieldName         ReportSortField = "Name"
+	ReportSortFieldState        ReportSortField = "State"
+	ReportSortFieldCreatorName  ReportSortField = "CreatorName"
+	ReportSortFieldCreatedDate  ReportSortField = "CreatedDate"
+	ReportSortFieldArchivedDate ReportSortField = "ArchivedDate"
+)
+
+type ReportSort struct {
+	Field ReportSortField
+	Desc  bool
+}
+
+type ListReportsInput struct {
+	// Name matches reports whose name contains it.
+	Name        string
+	CreatorName string
+	States      []ReportState
+	Types       []ReportType
+	// SortBy and ThenBy order the reports. They default to archived reports
+	// last, then the newest first.
+	SortBy ReportSort
+	ThenBy ReportSort
+	// PageSize defaults to 20 and PageNumber, counted from 1, to the first
+	// page.
+	PageSize   int
+	PageNumber int
+}
+
+// listFilter, listPaging and listSorting make up the search model the CRM
+// list endpoints take.
+type listFilter struct {
+	Name       string   `json:"Name"`
+	Comparison int      `json:"Comparision"`
+	Values     []string `json:"Values"`
+}
+
+const (
+	listComparisonIn       = 0
+	listComparisonContains = 2
+)
+
+type listPaging struct {
+	PageSize   int `json:"PageSize"`
+	PageNumber int `json:"PageNumber"`
+}
+
+type listSorting struct {
+	Name      string `json:"Name"`
+	Direction string `json:"Direction"`
+}
+
+func (s ReportSort) wire() listSorting {
+	direction := "asc"
+	if s.Desc {
+		direction = "desc"
+	}
+	return listSorting{Name: string(s.Field), Direction: direction}
+}
+
+func newListPaging(size, number int) listPaging {
+	if size <= 0 {
+		size = 20
+	}
+	if number <= 0 {
+		number = 1
+	}
+	return listPaging{PageSize: size, PageNumber: number}
 }
 
 type listReportsRequest struct {
-	Filters     []map[string]any `json:"Filters"`
-	Pageing     map[string]any   `json:"Pageing"`
-	Sorting     map[string]any   `json:"Sorting"`
-	SortingThen map[string]any   `json:"SortingThen"`
+	Filters     []listFilter `json:"Filters"`
+	Pageing     listPaging   `json:"Pageing"`
+	Sorting     listSorting  `json:"Sorting"`
+	SortingThen listSorting  `json:"SortingThen"`
 }
 
 type listReportsResponse struct {
 	Data []Report `json:"Data"`
 }
 
-func (c *client) ListReports(ctx context.Context, pageSize, pageNumber int) ([]Report, error) {
-	body, err := json.Marshal(listReportsRequest{
-		Filters: []map[string]any{
-			{"Name": "State", "Comparision": 0, "Values": []string{}},
-		},
-		Pageing:     map[string]any{"PageSize": pageSize, "PageNumber": pageNumber},
-		Sorting:     map[string]any{"Name": "ArchivedDate", "Direction": "asc"},
-		SortingThen: map[string]any{"Name": "CreatedDate", "Direction": "desc"},
-	})
+func (in ListReportsInput) toRequest() listReportsRequest {
+	filters := []listFilter{}
+	if in.Name != "" {
+		filters = append(filters, listFilter{Name: "Name", Comparison: listComparisonContains, Values: []string{in.Name}})
+	}
+	if in.CreatorName != "" {
+		filters = append(filters, listFilter{Name: "CreatorName", Comparison: listComparisonContains, Values: []string{in.CreatorName}})
+	}
+	if len(in.States) > 0 {
+		values := make([]string, len(in.States))
+		for i, s := range in.States {
+			values[i] = strconv.Itoa(int(s))
+		}
+		filters = append(filters, listFilter{Name: "State", Comparison: listComparisonIn, Values: values})
+	}
+	if len(in.Types) > 0 {
+		values := make([]string, len(in.Types))
+		for i, t := range in.Types {
+			values[i] = strconv.Itoa(int(t))
+		}
+		filters = append(filters, listFilter{Name: "ReportType", Comparison: listComparisonIn, Values: values})
+	}
+
+	sortBy, thenBy := in.SortBy, in.ThenBy
+	if sortBy.Field == "" {
+		sortBy = ReportSort{Field: ReportSortFieldArchivedDate}
+		thenBy = ReportSort{Field: ReportSortFieldCreatedDate, Desc: true}
+	}
+	if thenBy.Field == "" {
+		thenBy = sortBy
+	}
+
+	return listReportsRequest{
+		Filters:     filters,
+		Pageing:     newListPaging(in.PageSize, in.PageNumber),
+		Sorting:     sortBy.wire(),
+		SortingThen: thenBy.wire(),
+	}
+}
+
+func (c *client) ListReports(ctx context.Context, in ListReportsInput) ([]Report, error) {
+	body, err := json.Marshal(in.toRequest())
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := makeRequest[listReportsResponse](ctx, http.MethodPost, "/Report/List", bytes.NewReader(body), c, nil)
+	results, err := makeRequest[listReportsResponse](ctx, "ListReports", http.MethodPost, "/Report/List", bytes.NewReader(body), c, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +189,7 @@ func (c *client) CreateReport(ctx context.Context, in CreateReportInput) (*Creat
 		ReportID int32 `json:"AdHocReportId"`
 	}
 
-	r, err := makeRequest[resp](ctx, http.MethodPost, "/AdHocReport/Create", bytes.NewReader(body), c, nil)
+	r, err := makeRequest[resp](ctx, "CreateReport", http.MethodPost, "/AdHocReport/Create", bytes.NewReader(body), c, nil)
 	if err != nil {
 		return nil, err
 	}
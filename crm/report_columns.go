@@ -0,0 +1,131 @@
+package crm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// report_columns.json holds only columns whose IDs were read from requests the
+// CRM report builder sends; the rest of the player table is not catalogued
+// yet. To add a column, capture its ID and kind from the builder, add it to
+// the dump and rerun go generate.
+//
+//go:generate go run ./internal/gencolumns -in report_columns.json -out report_columns_gen.go
+
+var (
+	ErrUnknownReportColumn = errors.New("unknown report column")
+	ErrInvalidReportFilter = errors.New("invalid report filter")
+)
+
+// ReportColumnKind is the type of the values in a report column.
+type ReportColumnKind string
+
+const (
+	ReportColumnKindString ReportColumnKind = "string"
+	ReportColumnKindNumber ReportColumnKind = "number"
+	// ReportColumnKindID is an integer identifier, such as a player ID.
+	ReportColumnKindID     ReportColumnKind = "id"
+	ReportColumnKindAmount ReportColumnKind = "amount"
+	ReportColumnKindDate   ReportColumnKind = "date"
+	ReportColumnKindBool   ReportColumnKind = "bool"
+)
+
+// ReportColumnInfo describes a column of the CRM player table.
+type ReportColumnInfo struct {
+	Column      ReportColumn
+	ID          int
+	DisplayName string
+	Kind        ReportColumnKind
+	// Ops lists the filter operators the column accepts. When nil, the
+	// column accepts every operator of its kind.
+	Ops []ReportFilterOp
+}
+
+// SupportedOps returns the filter operators the column accepts.
+func (i ReportColumnInfo) SupportedOps() []ReportFilterOp {
+	if i.Ops != nil {
+		return i.Ops
+	}
+	return reportColumnKindOps[i.Kind]
+}
+
+var (
+	comparableFilterOps = []ReportFilterOp{
+		ReportFilterOpEq, ReportFilterOpLt, ReportFilterOpLte, ReportFilterOpGt, ReportFilterOpGte,
+	}
+
+	reportColumnKindOps = map[ReportColumnKind][]ReportFilterOp{
+		ReportColumnKindString: {ReportFilterOpEq},
+		ReportColumnKindNumber: comparableFilterOps,
+		ReportColumnKindID:     {ReportFilterOpEq},
+		ReportColumnKindAmount: comparableFilterOps,
+		ReportColumnKindDate:   comparableFilterOps,
+		ReportColumnKindBool:   {ReportFilterOpEq},
+	}
+)
+
+var reportColumnsByName = func() map[ReportColumn]ReportColumnInfo {
+	m := make(map[ReportColumn]ReportColumnInfo, len(reportColumnCatalog))
+	for _, info := range reportColumnCatalog {
+		m[info.Column] = info
+	}
+	return m
+}()
+
+// ReportColumns returns the catalog of known columns ordered by ID. It is not
+// the whole player table: it holds only the columns captured from the CRM
+// report builder so far, and filtering on any other column fails with
+// ErrUnknownReportColumn.
+func ReportColumns() []ReportColumnInfo {
+	return slices.Clone(reportColumnCatalog)
+}
+
+// Info looks the column up in the catalog.
+func (c ReportColumn) Info() (ReportColumnInfo, bool) {
+	info, ok := reportColumnsByName[c]
+	return info, ok
+}
+
+// ID returns the CRM ID of the column, or 0 if it is not in the catalog.
+func (c ReportColumn) ID() int {
+	return reportColumnsByName[c].ID
+}
+
+// Kind returns the value kind of the column, or "" if it is not in the
+// catalog.
+func (c ReportColumn) Kind() ReportColumnKind {
+	return reportColumnsByName[c].Kind
+}
+
+func (f ReportFilter) validate() (ReportColumnInfo, error) {
+	info, ok := f.Column.Info()
+	if !ok {
+		return ReportColumnInfo{}, fmt.Errorf("%w: %q", ErrUnknownReportColumn, f.Column)
+	}
+	if !slices.Contains(info.SupportedOps(), f.Op) {
+		return ReportColumnInfo{}, fmt.Errorf("%w: %s does not support %q", ErrInvalidReportFilter, f.Column, f.Op)
+	}
+	if !fitsKind(f.Value, info.Kind) {
+		return ReportColumnInfo{}, fmt.Errorf("%w: %s %s: %T does not fit a %s column", ErrInvalidReportFilter, f.Column, f.Op, f.Value, info.Kind)
+	}
+	return info, nil
+}
+
+// fitsKind reports whether a value can be compared with a column of
+// the given kind.
+func fitsKind(v ReportFilterValue, kind ReportColumnKind) bool {
+	switch v.(type) {
+	case ReportFilterValueString:
+		return kind == ReportColumnKindString
+	case ReportFilterValueNumber:
+		return kind == ReportColumnKindNumber || kind == ReportColumnKindID
+	case ReportFilterValueAmount:
+		return kind == ReportColumnKindAmount
+	case ReportFilterValueDate:
+		return kind == ReportColumnKindDate
+	case ReportFilterValueBool:
+		return kind == ReportColumnKindBool
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+// Code generated by gencolumns from report_columns.json; DO NOT EDIT.
+
+package crm
+
+const (
+	ReportColumnPlayerID           ReportColumn = "PlayerId"
+	ReportColumnRegistrationDate   ReportColumn = "RegistrationDate"
+	ReportColumnDepositDate        ReportColumn = "DepositDate"
+	ReportColumnTotalDepositAmount ReportColumn = "TotalDepositAmount"
+	ReportColumnDepositActivity    ReportColumn = "DepositActivity"
+	ReportColumnLastDepositDate    ReportColumn = "LastDepositDate"
+)
+
+var reportColumnCatalog = []ReportColumnInfo{
+	{
+		Column:      ReportColumnPlayerID,
+		ID:          38,
+		DisplayName: "Player ID",
+		Kind:        ReportColumnKindID,
+	},
+	{
+		Column:      ReportColumnRegistrationDate,
+		ID:          61,
+		DisplayName: "Registration Date",
+		Kind:        ReportColumnKindDate,
+	},
+	{
+		Column:      ReportColumnDepositDate,
+		ID:          97,
+		DisplayName: "Deposit Date",
+		Kind:        ReportColumnKindDate,
+	},
+	{
+		Column:      ReportColumnTotalDepositAmount,
+		ID:          223,
+		DisplayName: "Total Deposit Amount",
+		Kind:        ReportColumnKindAmount,
+	},
+	{
+		Column:      ReportColumnDepositActivity,
+		ID:          634,
+		DisplayName: "Deposit Activity",
+		Kind:        ReportColumnKindBool,
+	},
+	{
+		Column:      ReportColumnLastDepositDate,
+		ID:          712,
+		DisplayName: "Last Deposit Date",
+		Kind:        ReportColumnKindDate,
+	},
+}
@@ -0,0 +1,165 @@
+package crm
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCreateReportInput_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      CreateReportInput
+		wantErr error
+	}{
+		{
+			name: "valid",
+			in: CreateReportInput{
+				Columns: []ReportColumn{ReportColumnPlayerID, ReportColumnTotalDepositAmount},
+				Filters: []ReportFilter{
+					{Column: ReportColumnRegistrationDate, Op: ReportFilterOpGte, Value: ReportFilterValueDate{Time: time.Now()}},
+					{Column: ReportColumnDepositActivity, Op: ReportFilterOpEq, Value: ReportFilterValueBool{Value: true}},
+				},
+			},
+		},
+		{
+			name:    "unknown column",
+			in:      CreateReportInput{Columns: []ReportColumn{"Nope"}},
+			wantErr: ErrUnknownReportColumn,
+		},
+		{
+			name: "unknown filter column",
+			in: CreateReportInput{Filters: []ReportFilter{
+				{Column: "Nope", Op: ReportFilterOpEq, Value: ReportFilterValueBool{}},
+			}},
+			wantErr: ErrUnknownReportColumn,
+		},
+		{
+			name: "unsupported op",
+			in: CreateReportInput{Filters: []ReportFilter{
+				{Column: ReportColumnDepositActivity, Op: ReportFilterOpGt, Value: ReportFilterValueBool{}},
+			}},
+			wantErr: ErrInvalidReportFilter,
+		},
+		{
+			name: "value of another kind",
+			in: CreateReportInput{Filters: []ReportFilter{
+				{Column: ReportColumnRegistrationDate, Op: ReportFilterOpEq, Value: ReportFilterValueAmount{Amount: 1}},
+			}},
+			wantErr: ErrInvalidReportFilter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := tt.in.toPayload()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			cols := payload.QueryConfiguration.Columns
+			if len(cols) != 2 || cols[0].ID != 38 || cols[1].ID != 223 {
+				t.Fatalf("got columns %+v, want IDs 38 and 223", cols)
+			}
+			if f := payload.QueryConfiguration.Filters[0][0]; f.Column.ID != 61 || f.Comparison != 5 {
+				t.Fatalf("got filter %+v, want column 61 compared with gte", f)
+			}
+		})
+	}
+}
+
+func TestReportColumns(t *testing.T) {
+	columns := ReportColumns()
+	for i, info := range columns {
+		if i > 0 && columns[i-1].ID >= info.ID {
+			t.Fatalf("catalog is not ordered by ID at %s", info.Column)
+		}
+		if got, ok := info.Column.Info(); !ok || got.ID != info.ID {
+			t.Fatalf("lookup of %s returned %+v", info.Column, got)
+		}
+		if len(info.SupportedOps()) == 0 {
+			t.Fatalf("%s supports no filter operators", info.Column)
+		}
+	}
+	if ReportColumnTotalDepositAmount.Kind() != ReportColumnKindAmount {
+		t.Fatalf("got kind %s, want amount", ReportColumnTotalDepositAmount.Kind())
+	}
+}
+
+// The generated catalog must hold exactly the columns of the dump it was
+// generated from; rerun go generate after editing report_columns.json.
+func TestReportColumns_MatchDump(t *testing.T) {
+	raw, err := os.ReadFile("report_columns.json")
+	if err != nil {
+		t.Fatalf("failed to read dump: %v", err)
+	}
+	var dump []struct {
+		Name string `json:"name"`
+		ID   int    `json:"id"`
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(raw, &dump); err != nil {
+		t.Fatalf("failed to parse dump: %v", err)
+	}
+	if len(dump) != len(reportColumnCatalog) {
+		t.Fatalf("catalog has %d columns, dump has %d", len(reportColumnCatalog), len(dump))
+	}
+	for _, c := range dump {
+		info, ok := ReportColumn(c.Name).Info()
+		if !ok || info.ID != c.ID || string(info.Kind) != c.Kind {
+			t.Errorf("catalog has %+v for %s, dump has ID %d and kind %s", info, c.Name, c.ID, c.Kind)
+		}
+	}
+}
+
+func TestCreateReportInput_FilterGroups(t *testing.T) {
+	weekAgo := time.Now().AddDate(0, 0, -7)
+	in := CreateReportInput{
+		Columns: []ReportColumn{ReportColumnPlayerID},
+		Filters: []ReportFilter{
+			{Column: ReportColumnDepositActivity, Op: ReportFilterOpEq, Value: ReportFilterValueBool{Value: true}},
+		},
+		FilterGroups: [][]ReportFilter{
+			{{Column: ReportColumnLastDepositDate, Op: ReportFilterOpGte, Value: ReportFilterValueDate{Time: weekAgo}}},
+			{
+				{Column: ReportColumnRegistrationDate, Op: ReportFilterOpGte, Value: ReportFilterValueDate{Time: weekAgo}},
+				{Column: ReportColumnPlayerID, Op: ReportFilterOpEq, Value: ReportFilterValueNumber{Value: 1001}},
+			},
+		},
+	}
+	payload, err := in.toPayload()
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+
+	groups := payload.QueryConfiguration.Filters
+	if len(groups) != 2 || len(groups[0]) != 2 || len(groups[1]) != 3 {
+		t.Fatalf("got groups %+v, want the base filter in both groups", groups)
+	}
+	for _, group := range groups {
+		if base := group[0]; base.Column.ID != 634 || base.Comparison != 0 {
+			t.Fatalf("got base filter %+v, want deposit activity eq", base)
+		}
+	}
+	if player := groups[1][2]; player.Column.ID != 38 || player.Comparison != 0 {
+		t.Fatalf("got player filter %+v", player)
+	}
+
+	invalid := []ReportFilter{
+		{Column: ReportColumnPlayerID, Op: ReportFilterOpGt, Value: ReportFilterValueNumber{Value: 1}},
+		{Column: ReportColumnPlayerID, Op: ReportFilterOpEq, Value: ReportFilterValueString{Value: "1"}},
+		{Column: ReportColumnRegistrationDate, Op: "between", Value: ReportFilterValueDate{}},
+	}
+	for _, f := range invalid {
+		in := CreateReportInput{FilterGroups: [][]ReportFilter{{f}}}
+		if _, err := in.toPayload(); !errors.Is(err, ErrInvalidReportFilter) {
+			t.Errorf("filter %+v: got error %v, want %v", f, err, ErrInvalidReportFilter)
+		}
+	}
+	if _, err := (CreateReportInput{FilterGroups: [][]ReportFilter{{}}}).toPayload(); !errors.Is(err, ErrInvalidReportFilter) {
+		t.Errorf("empty group: got error %v, want %v", err, ErrInvalidReportFilter)
+	}
+}
@@ -8,48 +8,77 @@ import (
 	"strconv"
 )
 
+type ListReportResultsInput struct {
+	ReportID int32
+	// Name matches results whose name contains it.
+	Name   string
+	States []ReportResultState
+	// SortBy and ThenBy order the results. They default to archived results
+	// last, then the newest first.
+	SortBy ReportSort
+	ThenBy ReportSort
+	// PageSize defaults to 20 and PageNumber, counted from 1, to the first
+	// page.
+	PageSize   int
+	PageNumber int
+}
+
 type listReportResultsRequest struct {
-	ReportID    string         `json:"ReportId"`
-	Type        string         `json:"Type"`
-	SearchModel map[string]any `json:"Searchmodel"`
+	ReportID    string             `json:"ReportId"`
+	Type        string             `json:"Type"`
+	SearchModel listReportsRequest `json:"Searchmodel"`
 }
 
 type listReportResultsResponse struct {
 	Data []ReportResult `json:"Data"`
 }
 
-func (c *client) ListReportResults(ctx context.Context, reportID int32) ([]ReportResult, error) {
-	reportIDStr := strconv.Itoa(int(reportID))
-	body, err := json.Marshal(listReportResultsRequest{
-		ReportID: reportIDStr,
+func (in ListReportResultsInput) toRequest() listReportResultsRequest {
+	filters := []listFilter{
+		{Name: "Name", Comparison: listComparisonContains, Values: []string{in.Name}},
+	}
+	if len(in.States) > 0 {
+		values := make([]string, len(in.States))
+		for i, s := range in.States {
+			values[i] = strconv.Itoa(int(s))
+		}
+		filters = append(filters, listFilter{Name: "State", Comparison: listComparisonIn, Values: values})
+	}
+
+	sortBy, thenBy := in.SortBy, in.ThenBy
+	if sortBy.Field == "" {
+		sortBy = ReportSort{Field: ReportSortFieldArchivedDate}
+		thenBy = ReportSort{Field: ReportSortFieldCreatedDate, Desc: true}
+	}
+	if thenBy.Field == "" {
+		thenBy = sortBy
+	}
+
+	return listReportResultsRequest{
+		ReportID: strconv.Itoa(int(in.ReportID)),
 		Type:     "0",
-		SearchModel: map[string]any{
-			"Filters": []map[string]any{
-				{
-					"Comparision": 2,
-					"Name":        "Name",
-					"Values":      []string{""},
-				},
-			},
-			"Pageing": map[string]any{
-				"PageSize":   20,
-				"PageNumber": 1,
-			},
-			"Sorting": map[string]any{
-				"Name":      "ArchivedDate",
-				"Direction": "asc",
-			},
-			"SortingThen": map[string]any{
-				"Name":      "CreatedDate",
-				"Direction": "desc",
-			},
+		SearchModel: listReportsRequest{
+			Filters:     filters,
+			Pageing:     newListPaging(in.PageSize, in.PageNumber),
+			Sorting:     sortBy.wire(),
+			SortingThen: thenBy.wire(),
 		},
-	})
+	}
+}
+
+// ListReportResults returns the first page of results for a report.
+func (c *client) ListReportResults(ctx context.Context, reportID int32) ([]ReportResult, error) {
+	return c.ListReportResultsPage(ctx, ListReportResultsInput{ReportID: reportID})
+}
+
+// ListReportResultsPage returns the page of results that in selects.
+func (c *client) ListReportResultsPage(ctx context.Context, in ListReportResultsInput) ([]ReportResult, error) {
+	body, err := json.Marshal(in.toRequest())
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := makeRequest[listReportResultsResponse](ctx, http.MethodPost, "/ReportResult/List", bytes.NewReader(body), c, nil)
+	results, err := makeRequest[listReportResultsResponse](ctx, "ListReportResults", http.MethodPost, "/ReportResult/List", bytes.NewReader(body), c, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllReportResults(t *testing.T) {
+	var requests []listReportResultsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req listReportResultsRequest
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+		switch req.SearchModel.Pageing.PageNumber {
+		case 1:
+			fmt.Fprint(w, `{"Data": {"Data": [
+				{"AdHocReportResultId": 3, "State": 1, "CreatedDate": "2025-03-03T10:00:00Z"},
+				{"AdHocReportResultId": 2, "State": 2, "RowCount": 40, "CreatedDate": "2025-03-02T10:00:00Z", "FinishedDate": "2025-03-02T10:05:00Z"}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{"Data": {"Data": [
+				{"AdHocReportResultId": 1, "State": 3, "CreatedDate": "2025-03-01T10:00:00Z"}
+			]}}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var got []ReportResult
+	for r, err := range AllReportResults(context.Background(), c, ListReportResultsInput{
+		ReportID: 7,
+		States:   []ReportResultState{1, 2, 3},
+		PageSize: 2,
+	}) {
+		if err != nil {
+			t.Fatalf("failed to list results: %v", err)
+		}
+		got = append(got, r)
+	}
+
+	if len(got) != 3 || len(requests) != 2 {
+		t.Fatalf("got %d results in %d requests, want 3 in 2", len(got), len(requests))
+	}
+	for i, r := range got {
+		if want := ReportResultState(i + 1); r.State != want {
+			t.Fatalf("result %d: got state %d, want %d", r.ID, r.State, want)
+		}
+	}
+	if got[0].FinishedAt != nil || got[1].FinishedAt == nil || got[1].RowCount != 40 {
+		t.Fatalf("got results %+v", got)
+	}
+
+	req := requests[0]
+	if req.ReportID != "7" || len(req.SearchModel.Filters) != 2 {
+		t.Fatalf("got request %+v", req)
+	}
+	if f := req.SearchModel.Filters[1]; f.Name != "State" || f.Comparison != listComparisonIn || len(f.Values) != 3 {
+		t.Fatalf("got state filter %+v", f)
+	}
+}
+
+func TestListReportResults(t *testing.T) {
+	var req listReportResultsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"Data": {"Data": [{"AdHocReportResultId": 1}]}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	results, err := c.ListReportResults(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("failed to list results: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1 {
+		t.Fatalf("got results %+v", results)
+	}
+	if req.ReportID != "7" || req.SearchModel.Pageing.PageSize != 20 || req.SearchModel.Pageing.PageNumber != 1 {
+		t.Fatalf("got request %+v, want the first page of 20", req)
+	}
+	if req.SearchModel.Sorting.Name != "ArchivedDate" || req.SearchModel.SortingThen.Name != "CreatedDate" {
+		t.Fatalf("got sorting %+v then %+v", req.SearchModel.Sorting, req.SearchModel.SortingThen)
+	}
+}
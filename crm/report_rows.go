@@ -0,0 +1,433 @@
+package crm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/extrasoftorg/betconstruct/internal/decimal"
+)
+
+// defaultReportLocation is the time zone reports are exported in unless
+// DownloadOptions names another.
+var defaultReportLocation = time.FixedZone("UTC+3", 3*60*60)
+
+// defaultReportCurrency is the currency reports are exported in unless
+// DownloadOptions names another.
+const defaultReportCurrency = "TRY"
+
+var ErrNoReportRow = errors.New("no current report row")
+
+// Decimal is the exact decimal type backoffice.Decimal also uses, so report
+// amounts and backoffice money add up without float rounding.
+type Decimal = decimal.Decimal
+
+// Amount is a money value read from a report. Value holds the number exactly
+// as the workbook writes it.
+type Amount struct {
+	Value    Decimal
+	Currency string
+}
+
+// ReportRow holds the values of one report row. Values are int64 for player
+// IDs, time.Time for dates, Amount for amounts, bool for flags and string or
+// float64 for anything else. Empty cells are left out.
+type ReportRow map[ReportColumn]any
+
+type ReportRowsOptions struct {
+	// Location is the time zone dates are read in. It defaults to UTC+3.
+	Location *time.Location
+	// Currency is attached to amounts that don't name one. It defaults to
+	// TRY.
+	Currency string
+}
+
+// ReportRows reads a report result workbook row by row, in the style of
+// sql.Rows:
+//
+//	rows, err := crm.NewReportRows(bytes.NewReader(file), int64(len(file)), crm.ReportRowsOptions{})
+//	...
+//	defer rows.Close()
+//	for rows.Next() {
+//		var r struct {
+//			PlayerID int64     `crm:"PlayerId"`
+//			Joined   time.Time `crm:"RegistrationDate"`
+//		}
+//		if err := rows.Scan(&r); err != nil { ... }
+//	}
+//	if err := rows.Err(); err != nil { ... }
+//
+// The first row of the sheet is the header. Cells are decoded as they are
+// read, so large results don't have to fit in memory as a whole.
+type ReportRows struct {
+	sheet   *xlsxSheet
+	opts    ReportRowsOptions
+	columns []ReportColumn
+	row     ReportRow
+	err     error
+}
+
+func NewReportRows(r io.ReaderAt, size int64, opts ReportRowsOptions) (*ReportRows, error) {
+	if opts.Location == nil {
+		opts.Location = defaultReportLocation
+	}
+	if opts.Currency == "" {
+		opts.Currency = defaultReportCurrency
+	}
+
+	sheet, err := openXLSXSheet(r, size)
+	if err != nil {
+		return nil, err
+	}
+	header, err := sheet.next()
+	if err == io.EOF {
+		header, err = nil, nil
+	}
+	if err != nil {
+		sheet.Close()
+		return nil, err
+	}
+
+	columns := make([]ReportColumn, len(header))
+	for i, c := range header {
+		columns[i] = reportColumnFromHeader(c.value)
+	}
+	return &ReportRows{sheet: sheet, opts: opts, columns: columns}, nil
+}
+
+// Rows parses the downloaded workbook. The location and currency default to
+// the ones the file was downloaded with.
+func (r *RunResult) Rows(opts ReportRowsOptions) (*ReportRows, error) {
+	if opts.Location == nil {
+		opts.Location = r.download.Location
+	}
+	if opts.Currency == "" {
+		opts.Currency = r.download.Currency
+	}
+	return NewReportRows(bytes.NewReader(r.File), int64(len(r.File)), opts)
+}
+
+// Columns returns the report columns in sheet order.
+func (r *ReportRows) Columns() []ReportColumn {
+	return r.columns
+}
+
+// Next advances to the next row. It returns false at the end of the sheet or
+// on error; Err tells the two apart.
+func (r *ReportRows) Next() bool {
+	r.row = nil
+	if r.err != nil {
+		return false
+	}
+	for {
+		cells, err := r.sheet.next()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			r.err = err
+			return false
+		}
+		row, err := r.convert(cells)
+		if err != nil {
+			r.err = err
+			return false
+		}
+		if len(row) > 0 {
+			r.row = row
+			return true
+		}
+	}
+}
+
+// Row returns the current row.
+func (r *ReportRows) Row() ReportRow {
+	return r.row
+}
+
+func (r *ReportRows) Err() error {
+	return r.err
+}
+
+func (r *ReportRows) Close() error {
+	return r.sheet.Close()
+}
+
+// Scan copies the current row into the struct dst points to. Fields are
+// matched by their crm tag, holding the column name, and fields without a
+// matching value are left untouched. Values convert to any field of a
+// compatible kind; an Amount also fits a Decimal or, rounded, a float64 field.
+func (r *ReportRows) Scan(dst any) error {
+	if r.row == nil {
+		return ErrNoReportRow
+	}
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination must be a pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("crm"), ",")
+		if tag == "" || tag == "-" || !f.IsExported() {
+			continue
+		}
+		val, ok := r.row[ReportColumn(tag)]
+		if !ok {
+			continue
+		}
+		if err := assign(v.Field(i), val); err != nil {
+			return fmt.Errorf("column %s into field %s: %w", tag, f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *ReportRows) convert(cells []xlsxCell) (ReportRow, error) {
+	row := make(ReportRow, len(cells))
+	for i, c := range cells {
+		if i >= len(r.columns) || strings.TrimSpace(c.value) == "" {
+			continue
+		}
+		col := r.columns[i]
+		val, err := r.value(col, c)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col, err)
+		}
+		row[col] = val
+	}
+	return row, nil
+}
+
+func (r *ReportRows) value(col ReportColumn, c xlsxCell) (any, error) {
+	text := strings.TrimSpace(c.value)
+	switch col.Kind() {
+	case ReportColumnKindID:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil || f != math.Trunc(f) {
+			return nil, fmt.Errorf("invalid id %q", text)
+		}
+		return int64(f), nil
+	case ReportColumnKindDate:
+		return r.date(c)
+	case ReportColumnKindAmount:
+		return r.amount(text)
+	case ReportColumnKindBool:
+		return parseReportBool(text)
+	case ReportColumnKindNumber:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", text)
+		}
+		return f, nil
+	case ReportColumnKindString:
+		return c.value, nil
+	}
+
+	switch {
+	case c.typ == "b":
+		return text == "1", nil
+	case c.isText() || c.typ == "e":
+		return c.value, nil
+	case c.typ == "d" || r.sheet.isDate(c):
+		return r.date(c)
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return c.value, nil
+	}
+	return f, nil
+}
+
+var reportDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"02/01/2006 15:04:05",
+	"02/01/2006",
+}
+
+func (r *ReportRows) date(c xlsxCell) (time.Time, error) {
+	text := strings.TrimSpace(c.value)
+	if !c.isText() && c.typ != "d" {
+		serial, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", text)
+		}
+		return excelTime(serial, r.opts.Location), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return t.In(r.opts.Location), nil
+	}
+	for _, layout := range reportDateLayouts {
+		if t, err := time.ParseInLocation(layout, text, r.opts.Location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", text)
+}
+
+// excelTime converts a spreadsheet serial date, days since 1899-12-30 in the
+// wall clock of the sheet, to a time in loc.
+func excelTime(serial float64, loc *time.Location) time.Time {
+	days := math.Floor(serial)
+	ms := math.Round((serial - days) * 24 * 60 * 60 * 1000)
+	return time.Date(1899, 12, 30+int(days), 0, 0, 0, int(ms)*int(time.Millisecond), loc)
+}
+
+// amount parses a plain number or one with a currency code before or after
+// it, such as "1,250.50 TRY".
+func (r *ReportRows) amount(text string) (Amount, error) {
+	currency := r.opts.Currency
+	fields := strings.Fields(text)
+	if len(fields) == 2 {
+		switch {
+		case isCurrencyCode(fields[0]):
+			currency, text = fields[0], fields[1]
+		case isCurrencyCode(fields[1]):
+			text, currency = fields[0], fields[1]
+		}
+	}
+	number, ok := stripThousands(text)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", text)
+	}
+	value, err := decimal.Parse(number)
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid amount %q", text)
+	}
+	return Amount{Value: value, Currency: currency}, nil
+}
+
+// stripThousands removes the commas that group the integer digits of text in
+// threes. It reports false for any other comma, such as the decimal comma of
+// "1.250,50", rather than guess what it means.
+func stripThousands(text string) (string, bool) {
+	intPart, frac, hasFrac := strings.Cut(text, ".")
+	groups := strings.Split(intPart, ",")
+	if len(groups) > 1 && strings.TrimLeft(groups[0], "+-") == "" {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	if strings.Contains(frac, ",") {
+		return "", false
+	}
+	number := strings.Join(groups, "")
+	if hasFrac {
+		number += "." + frac
+	}
+	return number, true
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseReportBool(text string) (bool, error) {
+	switch strings.ToLower(text) {
+	case "1", "true", "yes":
+		return true, nil
+	case "0", "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid flag %q", text)
+}
+
+// reportColumnFromHeader maps a header cell to a catalog column by name or
+// display name, ignoring case, spaces and punctuation, so "Player ID" is
+// ReportColumnPlayerID. Unknown headers are kept as they are.
+func reportColumnFromHeader(header string) ReportColumn {
+	key := normalizeHeader(header)
+	for _, info := range reportColumnCatalog {
+		if normalizeHeader(string(info.Column)) == key || normalizeHeader(info.DisplayName) == key {
+			return info.Column
+		}
+	}
+	return ReportColumn(strings.TrimSpace(header))
+}
+
+func normalizeHeader(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	amountType  = reflect.TypeFor[Amount]()
+	decimalType = reflect.TypeFor[Decimal]()
+)
+
+func assign(field reflect.Value, val any) error {
+	if field.Kind() == reflect.Pointer {
+		p := reflect.New(field.Type().Elem())
+		if err := assign(p.Elem(), val); err != nil {
+			return err
+		}
+		field.Set(p)
+		return nil
+	}
+
+	v := reflect.ValueOf(val)
+	switch {
+	case v.Type().AssignableTo(field.Type()):
+		field.Set(v)
+		return nil
+	case v.Type() == amountType && field.Type() == decimalType:
+		field.Set(reflect.ValueOf(val.(Amount).Value))
+		return nil
+	case v.Type() == amountType && isFloatKind(field.Kind()):
+		field.SetFloat(val.(Amount).Value.Float64())
+		return nil
+	case v.Type() == timeType || v.Type() == amountType || field.Type() == timeType || field.Type() == decimalType:
+	case isNumberKind(v.Kind()) && isNumberKind(field.Kind()),
+		v.Kind() == field.Kind():
+		field.Set(v.Convert(field.Type()))
+		return nil
+	case field.Kind() == reflect.String:
+		field.SetString(fmt.Sprint(val))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", val, field.Type())
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
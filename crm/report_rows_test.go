@@ -0,0 +1,168 @@
+package crm
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testWorkbook(t *testing.T) []byte {
+	t.Helper()
+
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>
+		</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="worksheet" Target="worksheets/report.xml"/>
+		</Relationships>`,
+		"xl/sharedStrings.xml": `<sst>
+			<si><t>Player ID</t></si>
+			<si><t>Registration Date</t></si>
+			<si><r><t>Total Deposit </t></r><r><t>Amount</t></r></si>
+			<si><t>Deposit Activity</t></si>
+			<si><t>Note</t></si>
+		</sst>`,
+		"xl/styles.xml": `<styleSheet>
+			<numFmts><numFmt numFmtId="164" formatCode="dd/mm/yyyy hh:mm"/></numFmts>
+			<cellXfs><xf numFmtId="0"/><xf numFmtId="164"/></cellXfs>
+		</styleSheet>`,
+		"xl/worksheets/report.xml": `<worksheet><sheetData>
+			<row r="1">
+				<c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>
+				<c r="D1" t="s"><v>3</v></c><c r="E1" t="s"><v>4</v></c>
+			</row>
+			<row r="2">
+				<c r="A2"><v>1001</v></c><c r="B2" s="1"><v>45717.5</v></c><c r="C2"><v>250.5</v></c>
+				<c r="D2" t="b"><v>1</v></c><c r="E2" t="inlineStr"><is><t>vip</t></is></c>
+			</row>
+			<row r="3"></row>
+			<row r="4">
+				<c r="A4" t="str"><v>1002</v></c><c r="B4" t="inlineStr"><is><t>2025-03-02 08:15:00</t></is></c>
+				<c r="C4" t="inlineStr"><is><t>1,000.25 EUR</t></is></c>
+			</row>
+		</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close workbook: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReportRows(t *testing.T) {
+	file := testWorkbook(t)
+	rows, err := NewReportRows(bytes.NewReader(file), int64(len(file)), ReportRowsOptions{})
+	if err != nil {
+		t.Fatalf("failed to open rows: %v", err)
+	}
+	defer rows.Close()
+
+	wantColumns := []ReportColumn{
+		ReportColumnPlayerID, ReportColumnRegistrationDate, ReportColumnTotalDepositAmount,
+		ReportColumnDepositActivity, "Note",
+	}
+	if got := rows.Columns(); len(got) != len(wantColumns) {
+		t.Fatalf("got columns %v, want %v", got, wantColumns)
+	}
+	for i, col := range rows.Columns() {
+		if col != wantColumns[i] {
+			t.Fatalf("got columns %v, want %v", rows.Columns(), wantColumns)
+		}
+	}
+
+	type player struct {
+		ID         int64      `crm:"PlayerId"`
+		Registered time.Time  `crm:"RegistrationDate"`
+		Deposits   Amount     `crm:"TotalDepositAmount"`
+		Total      float64    `crm:"TotalDepositAmount"`
+		Exact      Decimal    `crm:"TotalDepositAmount"`
+		Active     *bool      `crm:"DepositActivity"`
+		Note       string     `crm:"Note"`
+		Missing    *time.Time `crm:"LastDepositDate"`
+	}
+	var got []player
+	for rows.Next() {
+		var p player
+		if err := rows.Scan(&p); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		got = append(got, p)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d rows, want 2", len(got))
+	}
+
+	first := got[0]
+	wantDate := time.Date(2025, 3, 1, 12, 0, 0, 0, defaultReportLocation)
+	if first.ID != 1001 || !first.Registered.Equal(wantDate) || first.Note != "vip" || first.Missing != nil {
+		t.Fatalf("got first row %+v", first)
+	}
+	if first.Deposits.Value.String() != "250.5" || first.Deposits.Currency != "TRY" || first.Total != 250.5 || first.Exact.String() != "250.5" {
+		t.Fatalf("got deposits %+v, total %v and exact %s, want 250.5 TRY", first.Deposits, first.Total, first.Exact)
+	}
+	if first.Active == nil || !*first.Active {
+		t.Fatalf("got active %v, want true", first.Active)
+	}
+
+	second := got[1]
+	wantDate = time.Date(2025, 3, 2, 8, 15, 0, 0, defaultReportLocation)
+	if second.ID != 1002 || !second.Registered.Equal(wantDate) || second.Deposits.Value.String() != "1000.25" || second.Deposits.Currency != "EUR" {
+		t.Fatalf("got second row %+v", second)
+	}
+}
+
+func TestReportRows_InvalidWorkbook(t *testing.T) {
+	file := []byte("not a zip")
+	_, err := NewReportRows(bytes.NewReader(file), int64(len(file)), ReportRowsOptions{})
+	if !errors.Is(err, ErrInvalidWorkbook) {
+		t.Fatalf("got error %v, want %v", err, ErrInvalidWorkbook)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "AB12": 27, "XFD1": maxColumn} {
+		if got, err := columnIndex(ref); err != nil || got != want {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", ref, got, err, want)
+		}
+	}
+	for _, ref := range []string{"1", "XFE1", "ZZZZZZZ1", "ZZZZZZZZZZZZZZ1"} {
+		if _, err := columnIndex(ref); !errors.Is(err, ErrInvalidWorkbook) {
+			t.Errorf("columnIndex(%q) error = %v, want %v", ref, err, ErrInvalidWorkbook)
+		}
+	}
+}
+
+func TestReportRows_Amount(t *testing.T) {
+	r := &ReportRows{}
+	for text, want := range map[string]string{
+		"1,250.50 TRY": "1250.50",
+		"-1,000,000":   "-1000000",
+		"250.5":        "250.5",
+	} {
+		if got, err := r.amount(text); err != nil || got.Value.String() != want {
+			t.Errorf("amount(%q) = %v, %v, want %s", text, got.Value, err, want)
+		}
+	}
+	for _, text := range []string{"1.250,50 TRY", "1,25", "12,50", ",250", "1,2500"} {
+		if got, err := r.amount(text); err == nil {
+			t.Errorf("amount(%q) = %v, want an error", text, got.Value)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListReports(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.URL.Path+" "+string(body))
+		switch r.URL.Path {
+		case "/api/en/Report/List":
+			fmt.Fprint(w, `{"Data": {"Data": [
+				{"ReportId": 7, "Name": "deposits", "State": 2, "ReportType": 0, "ArchivedDate": "2025-03-01T10:00:00Z"}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{"Data": null}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	reports, err := c.ListReports(ctx, ListReportsInput{
+		Name:   "dep",
+		States: []ReportState{1, 2},
+		SortBy: ReportSort{Field: ReportSortFieldName, Desc: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to list reports: %v", err)
+	}
+	if len(reports) != 1 || reports[0].State != 2 || reports[0].ReportType != 0 || reports[0].ArchivedAt == nil {
+		t.Fatalf("got reports %+v, want one archived report", reports)
+	}
+
+	var list listReportsRequest
+	if err := json.Unmarshal([]byte(calls[0][len("/api/en/Report/List "):]), &list); err != nil {
+		t.Fatalf("failed to decode list request: %v", err)
+	}
+	if len(list.Filters) != 2 || fmt.Sprint(list.Filters[1].Values) != "[1 2]" || list.Sorting != (listSorting{Name: "Name", Direction: "desc"}) {
+		t.Fatalf("got list request %+v", list)
+	}
+}
+
+// Report state and type codes the package has no name for must survive a
+// decode and encode unchanged.
+func TestReport_RoundTrip(t *testing.T) {
+	in := `{"ReportId":7,"Name":"","Description":"","CreatorName":"","State":9,"ReportType":4,"CreatedDate":"0001-01-01T00:00:00Z","ArchivedDate":null,"HasResults":false,"IsExported":false}`
+	var r Report
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if string(out) != in {
+		t.Fatalf("got %s, want %s", out, in)
+	}
+}
@@ -2,33 +2,16 @@ package crm
 
 import (
 	"fmt"
+	"slices"
 	"time"
 )
 
 type ReportColumn string
 
-func (c ReportColumn) ID() int {
-	return reportColumnIDMap[c]
-}
-
-const (
-	ReportColumnPlayerID           ReportColumn = "PlayerId"
-	ReportColumnRegistrationDate   ReportColumn = "RegistrationDate"
-	ReportColumnLastDepositDate    ReportColumn = "LastDepositDate"
-	ReportColumnTotalDepositAmount ReportColumn = "TotalDepositAmount"
-	ReportColumnDepositActivity    ReportColumn = "DepositActivity"
-	ReportColumnDepositDate        ReportColumn = "DepositDate"
-)
-
-var reportColumnIDMap = map[ReportColumn]int{
-	ReportColumnPlayerID:           38,
-	ReportColumnRegistrationDate:   61,
-	ReportColumnLastDepositDate:    712,
-	ReportColumnTotalDepositAmount: 223,
-	ReportColumnDepositActivity:    634,
-	ReportColumnDepositDate:        97,
-}
-
+// ReportFilterOp is the comparison of a ReportFilter. Only the operators
+// below are supported; not-equal, in and not-in lists, contains, starts-with,
+// between and is-null are left out until their CRM codes are captured. A
+// range can be built from Gte and Lte filters in the same group.
 type ReportFilterOp string
 
 const (
@@ -39,6 +22,10 @@ const (
 	ReportFilterOpGte ReportFilterOp = "gte"
 )
 
+// reportFilterOpMap holds the comparison codes seen in requests of the CRM
+// report builder. Add an operator only once its code has been captured the
+// same way: the CRM accepts any code, and a wrong one silently filters on
+// another comparison.
 var reportFilterOpMap = map[ReportFilterOp]int{
 	ReportFilterOpEq:  0,
 	ReportFilterOpLt:  6,
@@ -70,16 +57,33 @@ type ReportFilterValueDate struct {
 
 func (ReportFilterValueDate) isReportFilterValue() {}
 
+type ReportFilterValueString struct {
+	Value string
+}
+
+func (ReportFilterValueString) isReportFilterValue() {}
+
+type ReportFilterValueNumber struct {
+	Value float64
+}
+
+func (ReportFilterValueNumber) isReportFilterValue() {}
+
+// ReportFilter compares a column with Value.
 type ReportFilter struct {
-	Column int
+	Column ReportColumn
 	Op     ReportFilterOp
 	Value  ReportFilterValue
 }
 
 type CreateReportInput struct {
 	Name    string
-	Columns []int
+	Columns []ReportColumn
+	// Filters must all match.
 	Filters []ReportFilter
+	// FilterGroups matches rows that satisfy every filter of at least one
+	// group. Filters applies on top of each group.
+	FilterGroups [][]ReportFilter
 }
 
 type column struct {
@@ -128,6 +132,17 @@ type filterValueBool struct {
 	Data      filterValueBoolData `json:"Data"`
 }
 
+type filterValueScalarData struct {
+	HasTime   bool `json:"HasTime"`
+	Value     any  `json:"Value"`
+	ValueType int  `json:"ValueType"`
+}
+type filterValueScalar struct {
+	Operation int                   `json:"Operation"`
+	Argument  filterValueArgument   `json:"Argument"`
+	Data      filterValueScalarData `json:"Data"`
+}
+
 type filterValueAmountData struct {
 	CurrencyCode  string  `json:"CurrencyCode"`
 	OriginalValue float64 `json:"OriginalValue"`
@@ -147,66 +162,31 @@ type createReportPayload struct {
 func (in CreateReportInput) toPayload() (*createReportPayload, error) {
 	columns := make([]column, len(in.Columns))
 	for i, col := range in.Columns {
+		info, ok := col.Info()
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownReportColumn, col)
+		}
 		columns[i] = column{
-			ID: col,
+			ID: info.ID,
 		}
 	}
 
-	filters := make([]filter, len(in.Filters))
-	for i, filt := range in.Filters {
-		var filterVal any
-		switch val := filt.Value.(type) {
-		case ReportFilterValueAmount:
-			filterVal = filterValueAmount{
-				Data: filterValueAmountData{
-					CurrencyCode:  val.Currency,
-					OriginalValue: val.Amount,
-					IsEquivalent:  false,
-				},
-			}
-
-		case ReportFilterValueDate:
-			t := val.Time
-			hasTime := false
-			if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
-				hasTime = true
-			}
-
-			filterVal = filterValueDate{
-				Argument: filterValueArgument{
-					Value: nil,
-					Type:  0,
-				},
-				Data: filterValueDateData{
-					HasTime:   hasTime,
-					Value:     t,
-					ValueType: 0,
-				},
-				Operation: 0,
+	base, err := toFilters(in.Filters)
+	if err != nil {
+		return nil, err
+	}
+	groups := [][]filter{base}
+	if len(in.FilterGroups) > 0 {
+		groups = make([][]filter, len(in.FilterGroups))
+		for i, group := range in.FilterGroups {
+			if len(group) == 0 {
+				return nil, fmt.Errorf("%w: filter group %d is empty", ErrInvalidReportFilter, i)
 			}
-
-		case ReportFilterValueBool:
-			filterVal = filterValueBool{
-				Operation: 0,
-				Argument: filterValueArgument{
-					Value: nil,
-					Type:  0,
-				},
-				Data: filterValueBoolData{
-					HasTime:   false,
-					Value:     val.Value,
-					ValueType: 0,
-				},
+			filters, err := toFilters(group)
+			if err != nil {
+				return nil, err
 			}
-
-		default:
-			return nil, fmt.Errorf("invalid filter value type: %T", val)
-		}
-
-		filters[i] = filter{
-			Column:     column{ID: filt.Column},
-			Comparison: reportFilterOpMap[filt.Op],
-			Value:      filterVal,
+			groups[i] = append(slices.Clone(base), filters...)
 		}
 	}
 
@@ -219,7 +199,7 @@ func (in CreateReportInput) toPayload() (*createReportPayload, error) {
 			}{
 				ID: 4,
 			},
-			Filters: [][]filter{filters},
+			Filters: groups,
 		},
 	}, nil
 }
@@ -227,3 +207,97 @@ func (in CreateReportInput) toPayload() (*createReportPayload, error) {
 type CreateReportResponse struct {
 	ReportID int32
 }
+
+func toFilters(in []ReportFilter) ([]filter, error) {
+	filters := make([]filter, len(in))
+	for i, filt := range in {
+		info, err := filt.validate()
+		if err != nil {
+			return nil, err
+		}
+		value, err := toFilterValue(filt.Value)
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = filter{
+			Column:     column{ID: info.ID},
+			Comparison: reportFilterOpMap[filt.Op],
+			Value:      value,
+		}
+	}
+	return filters, nil
+}
+
+func toFilterValue(v ReportFilterValue) (any, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+
+	case ReportFilterValueAmount:
+		return filterValueAmount{
+			Data: filterValueAmountData{
+				CurrencyCode:  val.Currency,
+				OriginalValue: val.Amount,
+				IsEquivalent:  false,
+			},
+		}, nil
+
+	case ReportFilterValueDate:
+		t := val.Time
+		hasTime := false
+		if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
+			hasTime = true
+		}
+
+		return filterValueDate{
+			Argument: filterValueArgument{
+				Value: nil,
+				Type:  0,
+			},
+			Data: filterValueDateData{
+				HasTime:   hasTime,
+				Value:     t,
+				ValueType: 0,
+			},
+			Operation: 0,
+		}, nil
+
+	case ReportFilterValueBool:
+		return filterValueBool{
+			Operation: 0,
+			Argument: filterValueArgument{
+				Value: nil,
+				Type:  0,
+			},
+			Data: filterValueBoolData{
+				HasTime:   false,
+				Value:     val.Value,
+				ValueType: 0,
+			},
+		}, nil
+
+	case ReportFilterValueString:
+		return scalarFilterValue(val.Value), nil
+
+	case ReportFilterValueNumber:
+		return scalarFilterValue(val.Value), nil
+
+	default:
+		return nil, fmt.Errorf("invalid filter value type: %T", val)
+	}
+}
+
+func scalarFilterValue(v any) filterValueScalar {
+	return filterValueScalar{
+		Operation: 0,
+		Argument: filterValueArgument{
+			Value: nil,
+			Type:  0,
+		},
+		Data: filterValueScalarData{
+			HasTime:   false,
+			Value:     v,
+			ValueType: 0,
+		},
+	}
+}
@@ -1,16 +1,15 @@
 package crm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
-)
 
-const (
-	baseURL = "https://crm-t.betconstruct.com/api/en"
+	"github.com/extrasoftorg/betconstruct/internal/apierror"
+	"github.com/extrasoftorg/betconstruct/internal/middleware"
 )
 
 type response[T any] struct {
@@ -21,90 +20,214 @@ type response[T any] struct {
 
 func makeRequest[T any](
 	ctx context.Context,
+	op string,
 	method string,
 	path string,
 	body io.Reader,
 	c *client,
 	marshal func(r io.Reader) error,
 ) (*T, error) {
-	return doRequest[T](ctx, method, path, body, c, marshal, false)
+	return doRequest[T](ctx, op, method, path, body, c, marshal, c.canRefresh(), false)
 }
 
-var isRefreshing bool
-
+// doRequest sends the request through the middleware chain and, when refresh
+// is set, logs in again and retries it once after a 401. When redact is set
+// the middleware sees neither the request body nor the token, since the call
+// carries credentials.
 func doRequest[T any](
 	ctx context.Context,
+	op string,
 	method string,
 	path string,
 	body io.Reader,
 	c *client,
 	marshal func(r io.Reader) error,
-	isRetry bool,
+	refresh bool,
+	redact bool,
 ) (*T, error) {
-	fullURL := fmt.Sprintf("%s%s", baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
-	if err != nil {
-		return nil, err
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+	call := &Call{
+		Operation:   op,
+		Method:      method,
+		Path:        path,
+		RequestBody: payload,
+		Header:      make(http.Header),
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authentication", "Bearer "+c.authToken)
+	if redact {
+		call.RequestBody = nil
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	var data *T
+	base := DoerFunc(func(ctx context.Context, call *Call) Outcome {
+		if redact {
+			sent := *call
+			sent.RequestBody = payload
+			call = &sent
+		}
+		var out Outcome
+		data, out = doAttempts[T](ctx, call, c, marshal, refresh)
+		if redact {
+			out.Token = ""
+		}
+		return out
+	})
+	out := middleware.Chain(base, c.middleware).Do(ctx, call)
+	if out.Err != nil {
+		return nil, out.Err
 	}
-	defer resp.Body.Close()
+	if data == nil && marshal == nil {
+		return nil, ErrNoResponse
+	}
+	return data, nil
+}
+
+func doAttempts[T any](
+	ctx context.Context,
+	call *Call,
+	c *client,
+	marshal func(r io.Reader) error,
+	refresh bool,
+) (*T, Outcome) {
+	var out Outcome
+	fail := func(err error) (*T, Outcome) {
+		out.Err = err
+		return nil, out
+	}
+	method, path := call.Method, call.Path
+	token := c.tokens.current(ctx, refresh)
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		if resp.StatusCode == http.StatusUnauthorized && !isRetry && c.refreshOnExpiry && !isRefreshing {
-			isRefreshing = true
-			err := c.Login(ctx)
-			isRefreshing = false
-			if err != nil {
-				return nil, ErrUnauthorized
-			}
-			if seeker, ok := body.(io.Seeker); ok {
-				seeker.Seek(0, io.SeekStart)
-			}
-			return doRequest[T](ctx, method, path, body, c, marshal, true)
+	var resp *http.Response
+	isRetry := false
+	for {
+		if err := c.limiter.Wait(ctx, "", path); err != nil {
+			return fail(err)
+		}
+		out.Attempts++
+		out.Token = token
+		var err error
+		resp, err = send(ctx, call, token, c)
+		if err != nil {
+			return fail(err)
+		}
+		out.StatusCode = resp.StatusCode
+		if resp.StatusCode != http.StatusUnauthorized || !refresh || isRetry {
+			break
 		}
+		resp.Body.Close()
 
-		switch resp.StatusCode {
-		case http.StatusBadRequest:
-			return nil, ErrBadRequest
-		case http.StatusUnauthorized:
-			return nil, ErrUnauthorized
-		case http.StatusForbidden:
-			return nil, ErrForbidden
-		case http.StatusNotFound:
-			return nil, ErrNotFound
-		case http.StatusMethodNotAllowed:
-			return nil, ErrMethodNotAllowed
-		case http.StatusTooManyRequests:
-			return nil, ErrTooManyRequests
-		case http.StatusInternalServerError:
-			return nil, ErrInternalServerError
-		case http.StatusBadGateway:
-			return nil, ErrBadGateway
-		case http.StatusServiceUnavailable:
-			return nil, ErrServiceUnavailable
-		default:
-			return nil, ErrUnexpectedStatus
+		token, err = c.tokens.refresh(ctx, token)
+		if err != nil {
+			return fail(fmt.Errorf("%w: failed to refresh token: %w", ErrUnauthorized, err))
 		}
+		isRetry = true
+	}
+	defer resp.Body.Close()
+
+	if statusError(resp.StatusCode) != nil {
+		errBody := apierror.ReadBody(resp.Body)
+		out.BodySize = int64(len(errBody))
+		return fail(newAPIError(method, path, resp.StatusCode, errBody, out.Attempts))
 	}
 
 	if marshal != nil {
-		err := marshal(resp.Body)
-		return nil, err
+		body := &countingReader{r: resp.Body}
+		err := marshal(body)
+		out.BodySize = body.n
+		if err != nil {
+			return fail(err)
+		}
+		return nil, out
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	out.BodySize = int64(len(raw))
+	if err != nil {
+		return fail(err)
 	}
 
 	var data response[T]
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fail(err)
 	} else if data.HasError {
-		return nil, errors.New(data.AlertMessage)
+		return fail(&APIError{
+			Method:       method,
+			Path:         path,
+			StatusCode:   resp.StatusCode,
+			HasError:     true,
+			AlertMessage: data.AlertMessage,
+			Body:         apierror.Truncate(raw),
+			Attempts:     out.Attempts,
+		})
+	}
+
+	return &data.Data, out
+}
+
+func send(ctx context.Context, call *Call, token string, c *client) (*http.Response, error) {
+	var r io.Reader
+	if call.RequestBody != nil {
+		r = bytes.NewReader(call.RequestBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, call.Method, c.endpoint+call.Path, r)
+	if err != nil {
+		return nil, err
 	}
 
-	return &data.Data, nil
+	for key, values := range call.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authentication", "Bearer "+token)
+
+	return c.httpClient.Do(req)
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// statusError maps an HTTP status code to a sentinel error. It returns nil for
+// any 2xx status.
+func statusError(statusCode int) error {
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusInternalServerError:
+		return ErrInternalServerError
+	case http.StatusBadGateway:
+		return ErrBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	default:
+		return ErrUnexpectedStatus
+	}
 }
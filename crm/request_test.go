@@ -0,0 +1,234 @@
+package crm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the login endpoint, handing out "fresh-<n>" tokens for
+// "bc-token", and echoes the body of any other request made with a fresh token.
+func newTestServer(t *testing.T, logins *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/api/en/User/LoginWithPlatform" {
+			if string(body) != `"bc-token"` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			n := logins.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			fmt.Fprintf(w, `{"Data": "Bearer fresh-%d"}`, n)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authentication"), "Bearer fresh-") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, _ := json.Marshal(string(body))
+		fmt.Fprintf(w, `{"Data": %s}`, data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, authToken string) *client {
+	t.Helper()
+
+	c, err := New(
+		context.Background(),
+		WithBaseURL(srv.URL),
+		WithAuthToken(authToken),
+		WithRefreshOnExpiry(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	cl := c.(*client)
+	cl.betconstructToken = "bc-token"
+	return cl
+}
+
+func TestMakeRequest_ConcurrentRefreshLogsInOnce(t *testing.T) {
+	var logins atomic.Int32
+	srv := newTestServer(t, &logins)
+	c := newTestClient(t, srv, "expired")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			want := fmt.Sprintf("payload-%d", i)
+			// A MultiReader can't seek, so the retry relies on buffering.
+			body := io.MultiReader(strings.NewReader(want))
+			got, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", body, c, nil)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			if *got != want {
+				t.Errorf("got body %q, want %q", *got, want)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := logins.Load(); n != 1 {
+		t.Fatalf("got %d logins, want 1", n)
+	}
+	if got := c.AuthToken(); got != "fresh-1" {
+		t.Fatalf("got token %q, want fresh-1", got)
+	}
+}
+
+func TestMakeRequest_RefreshesExpiringJWT(t *testing.T) {
+	var logins atomic.Int32
+	srv := newTestServer(t, &logins)
+	c := newTestClient(t, srv, testJWT(time.Now().Add(5*time.Second)))
+
+	if _, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", strings.NewReader("x"), c, nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if n := logins.Load(); n != 1 {
+		t.Fatalf("got %d logins, want the token refreshed before the request", n)
+	}
+}
+
+func TestMakeRequest_NoRefreshWithoutOption(t *testing.T) {
+	var logins atomic.Int32
+	srv := newTestServer(t, &logins)
+	c := newTestClient(t, srv, "expired")
+	c.refreshOnExpiry = false
+
+	_, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", strings.NewReader("x"), c, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("got error %v, want a 401 APIError", err)
+	}
+	if n := logins.Load(); n != 0 {
+		t.Fatalf("got %d logins, want none", n)
+	}
+}
+
+func TestMakeRequest_APIError(t *testing.T) {
+	newClient := func(t *testing.T, status int, body string) *client {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			io.WriteString(w, body)
+		}))
+		t.Cleanup(srv.Close)
+		c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return c.(*client)
+	}
+
+	t.Run("status", func(t *testing.T) {
+		c := newClient(t, http.StatusBadGateway, "<html>bad gateway</html>")
+
+		_, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", strings.NewReader("x"), c, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !errors.Is(err, ErrBadGateway) {
+			t.Fatalf("got error %v, want %v", err, ErrBadGateway)
+		}
+		if apiErr.Method != http.MethodPost || apiErr.Path != "/Echo" || apiErr.HasError || apiErr.Attempts != 1 {
+			t.Fatalf("got %+v, want a POST /Echo status failure after 1 attempt", apiErr)
+		}
+		if apiErr.Body != "<html>bad gateway</html>" {
+			t.Fatalf("got body %q, want the response body", apiErr.Body)
+		}
+	})
+
+	t.Run("has error", func(t *testing.T) {
+		c := newClient(t, http.StatusOK, `{"Data":null,"HasError":true,"AlertMessage":"Report not found"}`)
+
+		_, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", strings.NewReader("x"), c, nil)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got error %T %v, want *APIError", err, err)
+		}
+		if !apiErr.HasError || apiErr.AlertMessage != "Report not found" || apiErr.StatusCode != http.StatusOK {
+			t.Fatalf("got %+v, want HasError with alert message", apiErr)
+		}
+	})
+}
+
+func TestNew_BaseURL(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+		io.WriteString(w, `{"Data": "ok"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL+"/"), WithLanguage("TR"), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := makeRequest[string](context.Background(), "Echo", http.MethodPost, "/Echo", nil, c.(*client), nil); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if got != "/api/tr/Echo" {
+		t.Fatalf("requested %s, want /api/tr/Echo", got)
+	}
+}
+
+func TestNew_ValidatesBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{"no scheme", []Option{WithBaseURL("crm.local")}, ErrInvalidBaseURL},
+		{"api segment", []Option{WithBaseURL("https://crm.local/api/en")}, ErrInvalidBaseURL},
+		{"empty language", []Option{WithLanguage("")}, ErrInvalidLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithAuthToken("token")}, tt.opts...)
+			if _, err := New(context.Background(), opts...); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Unix(1700000000, 0)
+	if got := jwtExpiry(testJWT(exp)); !got.Equal(exp) {
+		t.Errorf("got %v, want %v", got, exp)
+	}
+	for _, token := range []string{"", "opaque", "a.b.c", testJWT(time.Time{})} {
+		if got := jwtExpiry(token); !got.IsZero() {
+			t.Errorf("jwtExpiry(%q) = %v, want zero", token, got)
+		}
+	}
+}
+
+func testJWT(exp time.Time) string {
+	claims := "{}"
+	if !exp.IsZero() {
+		claims = fmt.Sprintf(`{"exp": %d}`, exp.Unix())
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
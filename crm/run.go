@@ -0,0 +1,196 @@
+package crm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPollInterval    = 2 * time.Second
+	defaultMaxPollInterval = 30 * time.Second
+	defaultPollBackoff     = 1.5
+	defaultRunTimeout      = 30 * time.Minute
+
+	// cleanupTimeout bounds RunOptions.Cleanup, which runs even when the
+	// caller's context is already done.
+	cleanupTimeout = 10 * time.Second
+)
+
+var ErrReportTimeout = errors.New("report run timed out")
+
+type RunOptions struct {
+	// PollInterval is the wait before the first check for a result. It
+	// defaults to 2s.
+	PollInterval time.Duration
+	// MaxPollInterval caps the wait between checks. It defaults to 30s.
+	MaxPollInterval time.Duration
+	// Backoff multiplies the wait after every check that finds nothing. It
+	// defaults to 1.5; 1 polls at a fixed rate.
+	Backoff float64
+	// Timeout bounds the whole run, so an execution that never produces a
+	// result does not poll forever. It defaults to 30m.
+	Timeout time.Duration
+	// Progress is called as the run moves from one stage to the next.
+	Progress func(RunProgress)
+	// Cleanup, when set, is called with the report ID once the run is over,
+	// whether it succeeded or not, to dispose of the report. The client has
+	// no call for deleting reports, so by default the report stays in the
+	// CRM.
+	Cleanup func(ctx context.Context, reportID int32) error
+	// Download sets the format, currency and time zone of the file.
+	Download DownloadOptions
+}
+
+type RunStage string
+
+const (
+	RunStageCreated     RunStage = "created"
+	RunStageExecuted    RunStage = "executed"
+	RunStagePolling     RunStage = "polling"
+	RunStageDownloading RunStage = "downloading"
+	RunStageDone        RunStage = "done"
+)
+
+type RunProgress struct {
+	Stage    RunStage
+	ReportID int32
+	ResultID int32
+	// Attempt counts the checks for a result so far.
+	Attempt int
+	Elapsed time.Duration
+}
+
+type RunResult struct {
+	ReportID int32
+	ResultID int32
+	// File is the result as downloaded, an xlsx workbook unless another
+	// format was asked for.
+	File []byte
+
+	download DownloadOptions
+}
+
+// RunReport creates an ad-hoc report, executes it, waits for its result and
+// downloads it. When opts.Cleanup is set and only the cleanup fails, the
+// result is returned together with the error.
+func (c *client) RunReport(ctx context.Context, in CreateReportInput, opts RunOptions) (*RunResult, error) {
+	opts = opts.withDefaults()
+	start := time.Now()
+	ctx, cancel := context.WithTimeoutCause(ctx, opts.Timeout, ErrReportTimeout)
+	defer cancel()
+
+	progress := func(p RunProgress) {
+		if opts.Progress != nil {
+			p.Elapsed = time.Since(start)
+			opts.Progress(p)
+		}
+	}
+
+	created, err := c.CreateReport(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+	reportID := created.ReportID
+	progress(RunProgress{Stage: RunStageCreated, ReportID: reportID})
+
+	res, err := c.runReport(ctx, reportID, opts, progress)
+	if err != nil && !errors.Is(err, ErrReportTimeout) && errors.Is(context.Cause(ctx), ErrReportTimeout) {
+		// A request cut short by the timeout only reports the deadline.
+		err = fmt.Errorf("%w: %w", ErrReportTimeout, err)
+	}
+	if opts.Cleanup != nil {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		if cleanupErr := opts.Cleanup(cleanupCtx, reportID); cleanupErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to clean up report %d: %w", reportID, cleanupErr))
+		}
+	}
+	return res, err
+}
+
+func (c *client) runReport(ctx context.Context, reportID int32, opts RunOptions, progress func(RunProgress)) (*RunResult, error) {
+	if err := c.ExecuteReport(ctx, reportID); err != nil {
+		return nil, fmt.Errorf("failed to execute report %d: %w", reportID, err)
+	}
+	progress(RunProgress{Stage: RunStageExecuted, ReportID: reportID})
+
+	interval := opts.PollInterval
+	for attempt := 1; ; attempt++ {
+		if err := sleep(ctx, interval); err != nil {
+			return nil, fmt.Errorf("report %d: %w", reportID, err)
+		}
+		interval = min(time.Duration(float64(interval)*opts.Backoff), opts.MaxPollInterval)
+		progress(RunProgress{Stage: RunStagePolling, ReportID: reportID, Attempt: attempt})
+
+		results, err := c.ListReportResults(ctx, reportID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list results of report %d: %w", reportID, err)
+		}
+		result, ok := latestResult(results)
+		if !ok {
+			continue
+		}
+
+		progress(RunProgress{Stage: RunStageDownloading, ReportID: reportID, ResultID: result.ID, Attempt: attempt})
+		var file bytes.Buffer
+		err = c.DownloadReport(ctx, result.ID, &file, opts.Download)
+		if errors.Is(err, ErrReportResultNotReady) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to download result %d: %w", result.ID, err)
+		}
+
+		progress(RunProgress{Stage: RunStageDone, ReportID: reportID, ResultID: result.ID, Attempt: attempt})
+		return &RunResult{ReportID: reportID, ResultID: result.ID, File: file.Bytes(), download: opts.Download}, nil
+	}
+}
+
+func (o RunOptions) withDefaults() RunOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultPollInterval
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = defaultMaxPollInterval
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = o.PollInterval
+	}
+	if o.Backoff < 1 {
+		o.Backoff = defaultPollBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultRunTimeout
+	}
+	o.Download = o.Download.withDefaults()
+	return o
+}
+
+func latestResult(results []ReportResult) (ReportResult, bool) {
+	if len(results) == 0 {
+		return ReportResult{}, false
+	}
+	latest := results[0]
+	for _, r := range results[1:] {
+		if r.CreatedAt.After(latest.CreatedAt) {
+			latest = r
+		}
+	}
+	return latest, true
+}
+
+// sleep waits for d, returning early with the context's cause if it is done
+// first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
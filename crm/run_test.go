@@ -0,0 +1,128 @@
+package crm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reportServer fakes the CRM endpoints RunReport goes through. The result
+// shows up after resultAfter polls and can be downloaded after downloadAfter
+// more attempts.
+type reportServer struct {
+	mu            sync.Mutex
+	resultAfter   int
+	downloadAfter int
+	polls         int
+	downloads     int
+}
+
+func (s *reportServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/en/AdHocReport/Create":
+		fmt.Fprint(w, `{"Data": {"AdHocReportId": 7}}`)
+	case "/api/en/Report/Execute":
+		fmt.Fprint(w, `{"Data": null}`)
+	case "/api/en/ReportResult/List":
+		s.polls++
+		if s.polls <= s.resultAfter {
+			fmt.Fprint(w, `{"Data": {"Data": []}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Data": {"Data": [
+			{"AdHocReportResultId": 1, "State": 2, "CreatedDate": "2025-03-01T10:00:00Z"},
+			{"AdHocReportResultId": 2, "State": 9, "CreatedDate": "2025-03-01T11:00:00Z"}
+		]}}`)
+	case "/api/en/AdHocReportResult/GetExcel":
+		s.downloads++
+		if s.downloads <= s.downloadAfter {
+			fmt.Fprint(w, `{"HasError": false, "Data": null}`)
+			return
+		}
+		fmt.Fprint(w, "xlsx")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newReportClient(t *testing.T, s *reportServer) Client {
+	t.Helper()
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.Background(), WithBaseURL(srv.URL), WithAuthToken("token"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestRunReport(t *testing.T) {
+	s := &reportServer{resultAfter: 2, downloadAfter: 1}
+	c := newReportClient(t, s)
+
+	var stages []RunStage
+	var cleaned []int32
+	res, err := c.RunReport(context.Background(), CreateReportInput{Name: "deposits"}, RunOptions{
+		PollInterval: time.Millisecond,
+		Progress: func(p RunProgress) {
+			stages = append(stages, p.Stage)
+		},
+		Cleanup: func(ctx context.Context, reportID int32) error {
+			cleaned = append(cleaned, reportID)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to run report: %v", err)
+	}
+	if res.ReportID != 7 || res.ResultID != 2 || string(res.File) != "xlsx" {
+		t.Fatalf("got %+v, want the latest result of report 7", res)
+	}
+	if len(cleaned) != 1 || cleaned[0] != 7 {
+		t.Fatalf("got cleanups %v, want report 7 cleaned up", cleaned)
+	}
+
+	want := []RunStage{
+		RunStageCreated, RunStageExecuted,
+		RunStagePolling, RunStagePolling, RunStagePolling, RunStageDownloading,
+		RunStagePolling, RunStageDownloading, RunStageDone,
+	}
+	if fmt.Sprint(stages) != fmt.Sprint(want) {
+		t.Fatalf("got stages %v, want %v", stages, want)
+	}
+}
+
+func TestRunReport_Timeout(t *testing.T) {
+	s := &reportServer{resultAfter: 1 << 30}
+	c := newReportClient(t, s)
+
+	var cleanupErr error
+	_, err := c.RunReport(context.Background(), CreateReportInput{Name: "deposits"}, RunOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+		Cleanup: func(ctx context.Context, reportID int32) error {
+			cleanupErr = ctx.Err()
+			return errors.New("cleanup failed")
+		},
+	})
+	if !errors.Is(err, ErrReportTimeout) {
+		t.Fatalf("got error %v, want %v", err, ErrReportTimeout)
+	}
+	if cleanupErr != nil {
+		t.Fatalf("cleanup ran on a done context: %v", cleanupErr)
+	}
+	if !strings.Contains(err.Error(), "cleanup failed") {
+		t.Fatalf("got error %v, want the cleanup failure joined in", err)
+	}
+}
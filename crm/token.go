@@ -0,0 +1,98 @@
+package crm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/extrasoftorg/betconstruct/internal/singleflight"
+)
+
+// tokenRefreshSkew is how long before a JWT expires it gets refreshed, so
+// requests in flight don't race the expiry.
+const tokenRefreshSkew = 30 * time.Second
+
+// tokenManager guards the CRM auth token and makes sure concurrent requests
+// that find it expired trigger a single login between them.
+type tokenManager struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	refreshes singleflight.Group[string]
+	login     func(ctx context.Context) (string, error)
+}
+
+func (m *tokenManager) get() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token
+}
+
+func (m *tokenManager) set(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+	m.expiresAt = jwtExpiry(token)
+}
+
+// current returns the token to send. When proactive is set and the token is a
+// JWT about to expire, it is refreshed first; a failed proactive refresh falls
+// back to the old token and leaves the decision to the server.
+func (m *tokenManager) current(ctx context.Context, proactive bool) string {
+	m.mu.Lock()
+	token, expiresAt := m.token, m.expiresAt
+	m.mu.Unlock()
+
+	if !proactive || expiresAt.IsZero() || time.Until(expiresAt) > tokenRefreshSkew {
+		return token
+	}
+	if fresh, err := m.refresh(ctx, token); err == nil {
+		return fresh
+	}
+	return token
+}
+
+// refresh replaces stale with a new token. Callers that arrive while a login
+// is in flight wait for it instead of starting another, and a caller whose
+// stale token was already replaced gets the new one right away. The login
+// runs detached from ctx and a failed one is backed off; see
+// singleflight.Group.
+func (m *tokenManager) refresh(ctx context.Context, stale string) (string, error) {
+	if token := m.get(); token != stale {
+		return token, nil
+	}
+	return m.refreshes.Do(ctx, func(ctx context.Context) (string, error) {
+		if token := m.get(); token != stale {
+			return token, nil
+		}
+		token, err := m.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		m.set(token)
+		return token, nil
+	})
+}
+
+// jwtExpiry reads the exp claim of a JWT without verifying it. It returns the
+// zero time for tokens that are not JWTs or carry no expiry.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(claims.Exp), 0)
+}
@@ -5,7 +5,25 @@ import (
 )
 
 type ReportResult struct {
-	ID          int32     `json:"AdHocReportResultId"`
-	CreatorName string    `json:"CreatorName"`
-	CreatedAt   time.Time `json:"CreatedDate"`
+	ID          int32             `json:"AdHocReportResultId"`
+	Name        string            `json:"Name"`
+	CreatorName string            `json:"CreatorName"`
+	State       ReportResultState `json:"State"`
+	RowCount    int64             `json:"RowCount"`
+	CreatedAt   time.Time         `json:"CreatedDate"`
+	// FinishedAt is nil while the execution is running.
+	FinishedAt *time.Time `json:"FinishedDate"`
 }
+
+// ReportResultState is the numeric state code the CRM gives a report result.
+// It is kept as sent, since what each code means has not been catalogued;
+// RunReport goes by whether the result downloads instead.
+type ReportResultState int
+
+// ReportState is the numeric state code the CRM gives a report. It is kept
+// as sent, since what each code means has not been catalogued.
+type ReportState int
+
+// ReportType is the numeric type code the CRM gives a report. It is kept as
+// sent; named values will follow once the codes are catalogued.
+type ReportType int
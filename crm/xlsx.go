@@ -0,0 +1,329 @@
+package crm
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidWorkbook = errors.New("invalid xlsx workbook")
+
+// xlsxCell is a raw cell as stored in the sheet, before it is converted to a
+// Go value.
+type xlsxCell struct {
+	typ   string
+	style int
+	value string
+}
+
+func (c xlsxCell) isText() bool {
+	return c.typ == "s" || c.typ == "str" || c.typ == "inlineStr"
+}
+
+// xlsxSheet streams the rows of the first worksheet of a workbook. Only the
+// shared strings and the date styles are held in memory.
+type xlsxSheet struct {
+	sheet      io.ReadCloser
+	dec        *xml.Decoder
+	strings    []string
+	dateStyles []bool
+}
+
+func openXLSXSheet(r io.ReaderAt, size int64) (*xlsxSheet, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	s := &xlsxSheet{}
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if s.strings, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+	if f, ok := files["xl/styles.xml"]; ok {
+		if s.dateStyles, err = readDateStyles(f); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidWorkbook, sheetPath)
+	}
+	if s.sheet, err = f.Open(); err != nil {
+		return nil, err
+	}
+	s.dec = xml.NewDecoder(s.sheet)
+	return s, nil
+}
+
+func (s *xlsxSheet) Close() error {
+	return s.sheet.Close()
+}
+
+// next returns the cells of the next row indexed by column, or io.EOF once
+// the sheet is exhausted.
+func (s *xlsxSheet) next() ([]xlsxCell, error) {
+	for {
+		tok, err := s.dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+		}
+		if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "row" {
+			return s.readRow()
+		}
+	}
+}
+
+func (s *xlsxSheet) readRow() ([]xlsxCell, error) {
+	var row []xlsxCell
+	for {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+		}
+		switch el := tok.(type) {
+		case xml.EndElement:
+			if el.Name.Local == "row" {
+				return row, nil
+			}
+		case xml.StartElement:
+			if el.Name.Local != "c" {
+				continue
+			}
+			var raw struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Style  int    `xml:"s,attr"`
+				Value  string `xml:"v"`
+				Inline struct {
+					Text string `xml:"t"`
+					Runs []struct {
+						Text string `xml:"t"`
+					} `xml:"r"`
+				} `xml:"is"`
+			}
+			if err := s.dec.DecodeElement(&raw, &el); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+			}
+
+			col := len(row)
+			if raw.Ref != "" {
+				if col, err = columnIndex(raw.Ref); err != nil {
+					return nil, err
+				}
+			}
+			c := xlsxCell{typ: raw.Type, style: raw.Style, value: raw.Value}
+			switch raw.Type {
+			case "s":
+				i, err := strconv.Atoi(raw.Value)
+				if err != nil || i < 0 || i >= len(s.strings) {
+					return nil, fmt.Errorf("%w: bad shared string %q", ErrInvalidWorkbook, raw.Value)
+				}
+				c.value = s.strings[i]
+			case "inlineStr":
+				c.value = raw.Inline.Text
+				for _, r := range raw.Inline.Runs {
+					c.value += r.Text
+				}
+			}
+			for len(row) <= col {
+				row = append(row, xlsxCell{})
+			}
+			row[col] = c
+		}
+	}
+}
+
+func (s *xlsxSheet) isDate(c xlsxCell) bool {
+	return c.style >= 0 && c.style < len(s.dateStyles) && s.dateStyles[c.style]
+}
+
+// maxColumn is the zero based index of XFD, the last column a sheet can have.
+const maxColumn = 16383
+
+// columnIndex turns the letters of a cell reference such as "AB12" into a
+// zero based column index.
+func columnIndex(ref string) (int, error) {
+	col := 0
+	for i, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			col = col*26 + int(r-'A'+1)
+			if col-1 > maxColumn {
+				break
+			}
+			continue
+		}
+		if i == 0 {
+			break
+		}
+		return col - 1, nil
+	}
+	return 0, fmt.Errorf("%w: bad cell reference %q", ErrInvalidWorkbook, ref)
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var out []string
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok || el.Name.Local != "si" {
+			continue
+		}
+		var si struct {
+			Text string `xml:"t"`
+			Runs []struct {
+				Text string `xml:"t"`
+			} `xml:"r"`
+		}
+		if err := dec.DecodeElement(&si, &el); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+		}
+		text := si.Text
+		for _, r := range si.Runs {
+			text += r.Text
+		}
+		out = append(out, text)
+	}
+}
+
+// readDateStyles reports, for every cell style, whether its number format
+// renders a date.
+func readDateStyles(f *zip.File) ([]bool, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := xml.NewDecoder(rc).Decode(&styles); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkbook, err)
+	}
+
+	custom := make(map[int]bool, len(styles.NumFmts))
+	for _, nf := range styles.NumFmts {
+		custom[nf.ID] = isDateFormat(nf.Code)
+	}
+	out := make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		id := xf.NumFmtID
+		if isDate, ok := custom[id]; ok {
+			out[i] = isDate
+			continue
+		}
+		out[i] = (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+	}
+	return out, nil
+}
+
+// isDateFormat guesses whether a custom number format shows a date or time by
+// looking for date tokens outside quoted text and brackets.
+func isDateFormat(code string) bool {
+	inQuote, inBracket := false, false
+	for _, r := range code {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '[':
+			inBracket = true
+		case r == ']':
+			inBracket = false
+		case inBracket:
+		case strings.ContainsRune("ymdhsYMDHS", r):
+			return true
+		}
+	}
+	return false
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wb, ok := files["xl/workbook.xml"]
+	if !ok {
+		return fallback, nil
+	}
+	var workbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeZipXML(wb, &workbook); err != nil {
+		return "", err
+	}
+	rels, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(workbook.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+	var relationships struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeZipXML(rels, &relationships); err != nil {
+		return "", err
+	}
+	for _, rel := range relationships.Items {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidWorkbook, f.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package apierror holds the error type shared by the accounts, backoffice and
+// crm clients.
+package apierror
+
+import (
+	"fmt"
+	"io"
+)
+
+// MaxBodyLen is how much of a response body Error keeps.
+const MaxBodyLen = 512
+
+// Error describes a failed API call. Status failures wrap the calling
+// package's sentinel for the status code, so errors.Is keeps working. Business
+// failures, where the service answers with HasError set, carry the
+// AlertMessage instead.
+type Error struct {
+	Method       string
+	Path         string
+	StatusCode   int
+	HasError     bool
+	AlertMessage string
+	// Body is the beginning of the response body, truncated to MaxBodyLen
+	// bytes.
+	Body     string
+	Attempts int
+	Err      error
+}
+
+// New returns a status failure wrapping err.
+func New(method, path string, statusCode int, body []byte, attempts int, err error) *Error {
+	return &Error{
+		Method:     method,
+		Path:       path,
+		StatusCode: statusCode,
+		Body:       Truncate(body),
+		Attempts:   attempts,
+		Err:        err,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.HasError {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.Path, e.AlertMessage)
+	}
+	return fmt.Sprintf("%s %s: %d %v", e.Method, e.Path, e.StatusCode, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Truncate keeps the first MaxBodyLen bytes of body.
+func Truncate(body []byte) string {
+	if len(body) > MaxBodyLen {
+		return string(body[:MaxBodyLen]) + "..."
+	}
+	return string(body)
+}
+
+// ReadBody reads just enough of r for Truncate to tell whether it was cut.
+func ReadBody(r io.Reader) []byte {
+	b, _ := io.ReadAll(io.LimitReader(r, MaxBodyLen+1))
+	return b
+}
@@ -0,0 +1,34 @@
+package apierror
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	err := New("GET", "/Client/Get", 404, []byte("missing"), 2, errNotFound)
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("got %v, want it to wrap the sentinel", err)
+	}
+	if got, want := err.Error(), "GET /Client/Get: 404 not found"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	business := &Error{Method: "POST", Path: "/Client/Add", HasError: true, AlertMessage: "Client exists"}
+	if got, want := business.Error(), "POST /Client/Add: Client exists"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	body := ReadBody(strings.NewReader(strings.Repeat("x", 2*MaxBodyLen)))
+	if got := Truncate(body); len(got) != MaxBodyLen+len("...") || !strings.HasSuffix(got, "...") {
+		t.Fatalf("got %d bytes, want %d plus an ellipsis", len(got), MaxBodyLen)
+	}
+	if got := Truncate([]byte("short")); got != "short" {
+		t.Fatalf("got %q, want short", got)
+	}
+}
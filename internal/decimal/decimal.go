@@ -0,0 +1,328 @@
+// Package decimal implements the exact decimal type shared by the backoffice
+// and crm packages for money amounts.
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalid  = errors.New("invalid decimal")
+	ErrOverflow = errors.New("decimal overflow")
+)
+
+// maxDecimalDigits bounds both the significant digits and the fractional
+// digits a Decimal keeps.
+const maxDecimalDigits = 18
+
+var maxDecimalCoef = new(big.Int).Exp(big.NewInt(10), big.NewInt(maxDecimalDigits), nil)
+
+// Decimal is an exact base-10 number, coef × 10^-scale. It holds up to 18
+// significant digits, which is plenty for amounts. Arithmetic whose exact
+// result needs more digits returns ErrOverflow instead of silently
+// losing precision. The zero value is 0.
+type Decimal struct {
+	coef  int64
+	scale int32
+}
+
+// New returns coef × 10^-scale, e.g. New(1050, 2) is 10.50. It
+// panics when the value does not fit in 18 digits, like a malformed literal.
+func New(coef int64, scale int32) Decimal {
+	d, err := decimalFromBig(big.NewInt(coef), scale)
+	if err != nil {
+		panic(fmt.Sprintf("decimal: New(%d, %d): %v", coef, scale, err))
+	}
+	return d
+}
+
+// Parse parses a plain or exponent decimal literal such as "-12.50" or
+// "1.5e3". Fractional digits beyond the 18 significant digits a Decimal holds
+// are rounded half away from zero; a larger integer part is an error.
+func Parse(s string) (Decimal, error) {
+	orig := s
+	invalid := func() (Decimal, error) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalid, orig)
+	}
+
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return invalid()
+		}
+		s, exp = s[:i], e
+	}
+
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return invalid()
+	}
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return invalid()
+		}
+	}
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return Decimal{}, nil
+	}
+	// Past this bound an exponent gives a value too large for 18 digits or
+	// one that rounds to zero, and scaling by it would cost time and memory
+	// exponential in its length.
+	bound := int64(maxDecimalDigits + 1 + len(intPart) + len(fracPart))
+	switch {
+	case exp > bound:
+		return Decimal{}, fmt.Errorf("%w: %q: %w", ErrInvalid, orig, ErrOverflow)
+	case exp < -bound:
+		return Decimal{}, nil
+	}
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale < math.MinInt32 || scale > math.MaxInt32 {
+		return invalid()
+	}
+
+	d, err := decimalFromBig(roundBig(coef, int32(scale)))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: %q: %w", ErrInvalid, orig, err)
+	}
+	return d, nil
+}
+
+// FromFloat converts f using its shortest decimal representation, so
+// amounts that were decoded from a JSON number convert back exactly.
+func FromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%w: %v", ErrInvalid, f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Add returns d + o, or ErrOverflow when the exact sum needs more than
+// 18 digits.
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	a, b, scale := align(d, o)
+	return decimalFromBig(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	return d.Add(o.Neg())
+}
+
+// Mul returns d × o. The result keeps the combined scale of both operands,
+// rounded to 18 fractional digits.
+func (d Decimal) Mul(o Decimal) (Decimal, error) {
+	p := new(big.Int).Mul(big.NewInt(d.coef), big.NewInt(o.coef))
+	return decimalFromBig(roundBig(p, d.scale+o.scale))
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: -d.coef, scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.coef < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef < 0:
+		return -1
+	case d.coef > 0:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) IsZero() bool {
+	return d.coef == 0
+}
+
+// Cmp returns -1, 0 or +1 depending on whether d is less than, equal to or
+// greater than o. 10.5 and 10.50 compare equal.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Round rounds d to places fractional digits, halves away from zero.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+	div := pow10(d.scale - places)
+	q, r := d.coef/div, d.coef%div
+	if r < 0 {
+		r = -r
+	}
+	if r >= div-r {
+		if d.coef < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Decimal{coef: q, scale: places}
+}
+
+// Float64 returns the nearest float64. Use it for display or ratios only.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d without an exponent, keeping its scale: 10.50 stays
+// "10.50".
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.coef, 10)
+	if d.scale == 0 {
+		return s
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if pad := int(d.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// StringFixed formats d rounded or zero-padded to exactly places fractional
+// digits.
+func (d Decimal) StringFixed(places int32) string {
+	d = d.Round(places)
+	s := d.String()
+	if d.scale >= places {
+		return s
+	}
+	if d.scale == 0 {
+		s += "."
+	}
+	return s + strings.Repeat("0", int(places-d.scale))
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one, as the
+// backoffice uses both encodings for amounts. null leaves d unchanged.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// align returns the coefficients of a and b at their common scale.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+	return scaleBig(a, scale), scaleBig(b, scale), scale
+}
+
+func scaleBig(d Decimal, scale int32) *big.Int {
+	v := big.NewInt(d.coef)
+	if scale > d.scale {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil))
+	}
+	return v
+}
+
+// roundBig drops fractional digits of v × 10^-scale, rounding half away from
+// zero, until the value fits in 18 significant and 18 fractional digits.
+func roundBig(v *big.Int, scale int32) (*big.Int, int32) {
+	drop := int64(scale) - maxDecimalDigits
+	if n := int64(len(new(big.Int).Abs(v).String())) - maxDecimalDigits; n > drop {
+		drop = n
+	}
+	drop = min(drop, int64(scale))
+	if drop <= 0 {
+		return v, scale
+	}
+
+	div := new(big.Int).Exp(big.NewInt(10), big.NewInt(drop), nil)
+	q, r := new(big.Int).QuoRem(v, div, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(div) >= 0 {
+		if v.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q, scale - int32(drop)
+}
+
+// decimalFromBig returns v × 10^-scale, dropping trailing zeros when the
+// value would not fit otherwise.
+func decimalFromBig(v *big.Int, scale int32) (Decimal, error) {
+	if v.Sign() == 0 {
+		return Decimal{}, nil
+	}
+	if scale < 0 {
+		v = new(big.Int).Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+	ten, r := big.NewInt(10), new(big.Int)
+	for scale > 0 && (scale > maxDecimalDigits || new(big.Int).Abs(v).Cmp(maxDecimalCoef) >= 0) {
+		q, rem := new(big.Int).QuoRem(v, ten, r)
+		if rem.Sign() != 0 {
+			break
+		}
+		v, scale = q, scale-1
+	}
+	if scale > maxDecimalDigits || new(big.Int).Abs(v).Cmp(maxDecimalCoef) >= 0 {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{coef: v.Int64(), scale: scale}, nil
+}
+
+func pow10(n int32) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,162 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"10.50", "10.50"},
+		{"-0.05", "-0.05"},
+		{".5", "0.5"},
+		{"+3", "3"},
+		{"1.5e3", "1500"},
+		{"125e-2", "1.25"},
+		{"123456789012345678", "123456789012345678"},
+		{"0.1234567890123456789", "0.123456789012345679"},
+		{"1.0000000000000000000", "1.00000000000000000"},
+		{"1e-25", "0"},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1.2.3", "abc", "1e", "1234567890123456789"} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want %v", in, err, ErrInvalid)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	var sum Decimal
+	for range 10 {
+		var err error
+		if sum, err = sum.Add(New(1, 1)); err != nil {
+			t.Fatalf("failed to add: %v", err)
+		}
+	}
+	if !sum.Equal(New(1, 0)) {
+		t.Fatalf("ten times 0.1 = %s, want 1", sum)
+	}
+
+	a, b := New(1050, 2), New(25, 1)
+	if got, err := a.Sub(b); err != nil || got.String() != "8.00" {
+		t.Errorf("10.50 - 2.5 = %s, %v, want 8.00", got, err)
+	}
+	if got, err := a.Mul(b); err != nil || got.String() != "26.250" {
+		t.Errorf("10.50 * 2.5 = %s, %v, want 26.250", got, err)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(New(105, 1)) != 0 {
+		t.Errorf("unexpected comparison of %s and %s", a, b)
+	}
+
+	rounding := []struct {
+		d      Decimal
+		places int32
+		want   string
+	}{
+		{New(12345, 3), 2, "12.35"},
+		{New(-12345, 3), 2, "-12.35"},
+		{New(12344, 3), 2, "12.34"},
+		{New(5, 1), 0, "1"},
+	}
+	for _, tt := range rounding {
+		if got := tt.d.Round(tt.places).String(); got != tt.want {
+			t.Errorf("%s.Round(%d) = %s, want %s", tt.d, tt.places, got, tt.want)
+		}
+	}
+	if got := New(5, 0).StringFixed(2); got != "5.00" {
+		t.Errorf("StringFixed(2) = %s, want 5.00", got)
+	}
+}
+
+// Values at the edges of the 18 digits must compare and fail cleanly instead
+// of panicking.
+func TestOverflow(t *testing.T) {
+	big, _ := Parse("100")
+	tiny, _ := Parse("0.000000000000000001")
+	if big.Cmp(tiny) != 1 || tiny.Cmp(big) != -1 {
+		t.Fatalf("got wrong order for %s and %s", big, tiny)
+	}
+	if _, err := big.Add(tiny); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got error %v, want %v", err, ErrOverflow)
+	}
+
+	// Trailing zeros are dropped when that makes the exact sum fit.
+	sum, err := New(5, 1).Add(New(999999999999999990, 18))
+	if err != nil || sum.String() != "1.49999999999999999" {
+		t.Fatalf("got %s, %v, want 1.49999999999999999", sum, err)
+	}
+
+	max, _ := Parse("999999999999999999")
+	if _, err := max.Add(New(1, 0)); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got error %v, want %v", err, ErrOverflow)
+	}
+	if _, err := max.Mul(max); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got error %v, want %v", err, ErrOverflow)
+	}
+	if got := tiny.StringFixed(20); got != "0.00000000000000000100" {
+		t.Fatalf("got %s, want the value padded to 20 places", got)
+	}
+}
+
+// Huge exponents are settled before any scaling, so they cost no more than
+// small ones.
+func TestParse_LargeExponent(t *testing.T) {
+	start := time.Now()
+	if _, err := Parse("1e2147483647"); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got error %v, want %v", err, ErrOverflow)
+	}
+	if d, err := Parse("1e-2147483648"); err != nil || !d.IsZero() {
+		t.Fatalf("got %s, %v, want 0", d, err)
+	}
+	if d, err := Parse("5e-19"); err != nil || d.String() != "0.000000000000000001" {
+		t.Fatalf("got %s, %v, want it rounded up to 18 places", d, err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("parsing took %v", d)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Number Decimal `json:"number"`
+		String Decimal `json:"string"`
+		Null   Decimal `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"number": 0.1, "string": " 1234.56 ", "null": null}`), &v); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if v.Number.String() != "0.1" || v.String.String() != "1234.56" || !v.Null.IsZero() {
+		t.Fatalf("got %s, %s, %s", v.Number, v.String, v.Null)
+	}
+
+	// Amounts with more digits than a Decimal holds are rounded, not rejected.
+	var long Decimal
+	if err := json.Unmarshal([]byte(`0.1234567890123456789`), &long); err != nil || long.String() != "0.123456789012345679" {
+		t.Fatalf("got %s, %v, want 0.123456789012345679", long, err)
+	}
+
+	b, err := json.Marshal(v.String)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if string(b) != "1234.56" {
+		t.Fatalf("got %s, want 1234.56", b)
+	}
+}
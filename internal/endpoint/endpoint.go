@@ -0,0 +1,75 @@
+// Package endpoint validates the base URLs and language segments accepted by
+// the accounts, backoffice and crm clients.
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrInvalidBaseURL  = errors.New("invalid base url")
+	ErrInvalidLanguage = errors.New("invalid language")
+)
+
+// BaseURL validates baseURL and returns it without a trailing slash.
+func BaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBaseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%w: unsupported scheme %q", ErrInvalidBaseURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w: missing host", ErrInvalidBaseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w: unexpected query or fragment", ErrInvalidBaseURL)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// Join validates baseURL and language and joins them into the API endpoint,
+// such as https://example.com/api/en. The base URL must not already contain
+// the /api segment.
+func Join(baseURL, language string) (string, error) {
+	base, err := BaseURL(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if hasAPISegment(base) {
+		return "", fmt.Errorf("%w: %q already contains the /api segment", ErrInvalidBaseURL, baseURL)
+	}
+
+	language = strings.ToLower(language)
+	if !isLanguage(language) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLanguage, language)
+	}
+	return base + "/api/" + language, nil
+}
+
+// hasAPISegment reports whether base ends in /api or /api/<language>.
+func hasAPISegment(base string) bool {
+	u, _ := url.Parse(base)
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	n := len(segments)
+	if segments[n-1] == "api" {
+		return true
+	}
+	return n >= 2 && segments[n-2] == "api" && isLanguage(strings.ToLower(segments[n-1]))
+}
+
+func isLanguage(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
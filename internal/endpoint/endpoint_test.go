@@ -0,0 +1,33 @@
+package endpoint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		language string
+		want     string
+		wantErr  error
+	}{
+		{"https://example.com", "en", "https://example.com/api/en", nil},
+		{"https://example.com/", "pt-BR", "https://example.com/api/pt-br", nil},
+		{"http://127.0.0.1:8080/proxy/", "tr", "http://127.0.0.1:8080/proxy/api/tr", nil},
+		{"https://example.com/api/en", "en", "", ErrInvalidBaseURL},
+		{"https://example.com/api/", "en", "", ErrInvalidBaseURL},
+		{"example.com", "en", "", ErrInvalidBaseURL},
+		{"ftp://example.com", "en", "", ErrInvalidBaseURL},
+		{"https://example.com?x=1", "en", "", ErrInvalidBaseURL},
+		{"https://example.com", "", "", ErrInvalidLanguage},
+		{"https://example.com", "en/../x", "", ErrInvalidLanguage},
+	}
+
+	for _, tt := range tests {
+		got, err := Join(tt.baseURL, tt.language)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("Join(%q, %q) = %q, %v, want %q, %v", tt.baseURL, tt.language, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
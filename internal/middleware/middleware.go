@@ -0,0 +1,67 @@
+// Package middleware defines the call middleware shared by the backoffice and
+// crm clients.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+var ErrNoResponse = errors.New("middleware returned no response")
+
+// Call is one logical client call, such as a ListDeposits, on its way through
+// the middleware chain. Middleware may change it before passing it on.
+type Call struct {
+	// Operation is the name of the client method, e.g. "ListDeposits".
+	Operation string
+	Method    string
+	Path      string
+	// RequestBody is the JSON sent, nil when there is none. It is also nil
+	// for calls that carry credentials, such as the crm Login, and setting
+	// it on them has no effect.
+	RequestBody []byte
+	// Header is added to every HTTP request of the call, which is where
+	// tracing middleware puts its propagation headers.
+	Header http.Header
+}
+
+// Outcome is what came of a Call, across all the token refreshes and retries
+// it took.
+type Outcome struct {
+	// StatusCode is the status of the last response, or 0 if none arrived.
+	StatusCode int
+	// Token is the token the last request went out with, empty for calls
+	// that carry credentials. It grants access to the API, so middleware
+	// should not log or export it.
+	Token    string
+	Attempts int
+	// BodySize is the size of the last response body read, streamed
+	// downloads included.
+	BodySize int64
+	Err      error
+}
+
+type Doer interface {
+	Do(ctx context.Context, call *Call) Outcome
+}
+
+type DoerFunc func(ctx context.Context, call *Call) Outcome
+
+func (f DoerFunc) Do(ctx context.Context, call *Call) Outcome {
+	return f(ctx, call)
+}
+
+// Middleware wraps the Doer that performs a call. It can observe the call
+// and its outcome, change the call, or answer without calling next, in which
+// case a nil Err turns into ErrNoResponse since there is nothing to decode.
+type Middleware func(next Doer) Doer
+
+// Chain wraps base in mw. The first middleware is the outermost.
+func Chain(base Doer, mw []Middleware) Doer {
+	d := base
+	for i := len(mw) - 1; i >= 0; i-- {
+		d = mw[i](d)
+	}
+	return d
+}
@@ -0,0 +1,167 @@
+// Package ratelimit implements the client-side request limits of the
+// backoffice and crm clients.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit caps the request rate to the paths under Prefix, separately for
+// every key the client waits with. A trailing * is ignored, so "/Report/*"
+// and "/Report/" are the same, and "" matches every path.
+type Limit struct {
+	Prefix string
+	// Rate is the sustained number of requests per second. Zero or less
+	// leaves the paths unlimited.
+	Rate float64
+	// Burst is how many requests may go out at once after a quiet spell. It
+	// defaults to the rate rounded up, and to at least 1.
+	Burst int
+}
+
+type Limiter struct {
+	limits []Limit
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*tokenBucket
+}
+
+type bucketKey struct {
+	key    string
+	prefix string
+}
+
+func New(limits []Limit) *Limiter {
+	limits = slices.Clone(limits)
+	for i := range limits {
+		limits[i].Prefix = strings.TrimSuffix(limits[i].Prefix, "*")
+	}
+	slices.SortStableFunc(limits, func(a, b Limit) int {
+		return len(b.Prefix) - len(a.Prefix)
+	})
+	return &Limiter{
+		limits:  limits,
+		buckets: make(map[bucketKey]*tokenBucket),
+	}
+}
+
+// Wait blocks until a request to path may go out under key. A request counts
+// against the limit with the longest matching prefix. When the wait would
+// outlast the deadline of ctx, it fails right away with
+// context.DeadlineExceeded. A nil Limiter never waits.
+func (l *Limiter) Wait(ctx context.Context, key, path string) error {
+	if l == nil {
+		return nil
+	}
+	i := slices.IndexFunc(l.limits, func(r Limit) bool {
+		return strings.HasPrefix(path, r.Prefix)
+	})
+	if i < 0 || l.limits[i].Rate <= 0 {
+		return nil
+	}
+	limit := l.limits[i]
+
+	now := time.Now()
+	b := l.bucket(bucketKey{key: key, prefix: limit.Prefix}, limit, now)
+	d := b.reserve(now)
+	if d <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		b.cancel()
+		return fmt.Errorf("rate limit wait of %s for %s outlasts the deadline: %w", d, path, context.DeadlineExceeded)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// bucket returns the bucket of k, creating it if needed. Creating one drops
+// the buckets that have refilled completely, since a new bucket would
+// behave the same; keys that come and go, such as tokens, don't pile up.
+func (l *Limiter) bucket(k bucketKey, limit Limit, now time.Time) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[k]; ok {
+		return b
+	}
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+	b := newTokenBucket(limit)
+	l.buckets[k] = b
+	return b
+}
+
+// tokenBucket hands out reservations: a caller takes a slot right away and
+// waits for as long as the bucket is in debt.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit Limit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = max(1, math.Ceil(limit.Rate))
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst}
+}
+
+// reserve takes a slot and returns how long to wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a slot that won't be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// full reports whether the bucket has refilled to its burst by now.
+func (b *tokenBucket) full(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// refill adds the slots earned since the last call. b.mu must be held.
+func (b *tokenBucket) refill(now time.Time) {
+	switch {
+	case b.last.IsZero():
+		b.last = now
+	case now.After(b.last):
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
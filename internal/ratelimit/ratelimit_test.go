@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := newTokenBucket(Limit{Rate: 2, Burst: 2})
+
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		if got := b.reserve(now); got != want {
+			t.Fatalf("reservation %d: got wait %s, want %s", i, got, want)
+		}
+	}
+	b.cancel()
+	if got := b.reserve(now.Add(time.Second)); got != 0 {
+		t.Fatalf("got wait %s after a second, want none", got)
+	}
+}
+
+// Buckets of keys that are no longer used must go once they have refilled.
+func TestLimiter_DropsRefilledBuckets(t *testing.T) {
+	l := New([]Limit{{Rate: 1000, Burst: 1}})
+	ctx := context.Background()
+	for _, key := range []string{"token-1", "token-2", "token-3"} {
+		if err := l.Wait(ctx, key, "/Client/GetClients"); err != nil {
+			t.Fatalf("failed to wait: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := len(l.buckets); n != 1 {
+		t.Fatalf("got %d buckets, want only the last one", n)
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	l := New([]Limit{{Prefix: "/Report/*", Rate: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, "", "/Report/Execute"); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	if err := l.Wait(ctx, "", "/Report/Execute"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := l.Wait(ctx, "", "/Client/GetClients"); err != nil {
+		t.Fatalf("got error %v for a path outside the limit", err)
+	}
+}
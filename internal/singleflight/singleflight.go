@@ -0,0 +1,120 @@
+// Package singleflight collapses concurrent token logins into one, shared by
+// the backoffice and crm token refreshes.
+package singleflight
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultBackoff    = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Group runs one call of fn at a time for every caller of Do. The zero value
+// is ready to use.
+type Group[T any] struct {
+	// Timeout bounds each call. It defaults to 30s.
+	Timeout time.Duration
+	// Backoff is how long the error of a failed call is handed out before fn
+	// runs again. It doubles with each failure in a row up to MaxBackoff and
+	// defaults to a second.
+	Backoff time.Duration
+	// MaxBackoff caps Backoff. It defaults to a minute.
+	MaxBackoff time.Duration
+
+	now func() time.Time
+
+	mu       sync.Mutex
+	inflight *call[T]
+	err      error
+	retryAt  time.Time
+	failures int
+}
+
+type call[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// Do returns the result of fn, joining a call that is already in flight
+// instead of starting another. fn runs on a context detached from ctx, so a
+// caller that gives up does not fail the others waiting for the same call;
+// ctx only bounds the wait. Within the backoff after a failure, Do returns
+// that failure without calling fn.
+func (g *Group[T]) Do(ctx context.Context, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+
+	g.mu.Lock()
+	c := g.inflight
+	if c == nil {
+		if g.err != nil && g.clock().Before(g.retryAt) {
+			err := g.err
+			g.mu.Unlock()
+			return zero, err
+		}
+		c = &call[T]{done: make(chan struct{})}
+		g.inflight = c
+		go g.run(context.WithoutCancel(ctx), c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+func (g *Group[T]) run(ctx context.Context, c *call[T], fn func(ctx context.Context) (T, error)) {
+	timeout := g.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c.val, c.err = fn(ctx)
+
+	g.mu.Lock()
+	g.inflight = nil
+	if c.err != nil {
+		g.failures++
+		g.err, g.retryAt = c.err, g.clock().Add(g.backoff())
+	} else {
+		g.failures, g.err = 0, nil
+	}
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// backoff returns the wait after the current run of failures. g.mu must be
+// held.
+func (g *Group[T]) backoff() time.Duration {
+	d, limit := g.Backoff, g.MaxBackoff
+	if d <= 0 {
+		d = defaultBackoff
+	}
+	if limit <= 0 {
+		limit = defaultMaxBackoff
+	}
+	for range g.failures - 1 {
+		if d >= limit {
+			break
+		}
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+func (g *Group[T]) clock() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+	return time.Now()
+}
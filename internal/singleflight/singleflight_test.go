@@ -0,0 +1,97 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_Do(t *testing.T) {
+	var g Group[int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		<-release
+		return int(calls.Add(1)), nil
+	}
+
+	// The first caller gives up; the others must still get the result.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := g.Do(ctx, fn)
+		first <- err
+	}()
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := g.Do(context.Background(), fn); err != nil || v != 1 {
+				t.Errorf("got %d, %v, want 1", v, err)
+			}
+		}()
+	}
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("got %d calls, want 1", n)
+	}
+}
+
+func TestGroup_Backoff(t *testing.T) {
+	now := time.Now()
+	g := Group[string]{Backoff: time.Second, MaxBackoff: 3 * time.Second, now: func() time.Time { return now }}
+	var calls atomic.Int32
+	errDown := errors.New("auth service down")
+	fn := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "", errDown
+	}
+	do := func() error {
+		_, err := g.Do(context.Background(), fn)
+		return err
+	}
+
+	steps := []struct {
+		after time.Duration
+		calls int32
+	}{
+		{0, 1},
+		{500 * time.Millisecond, 1},
+		{time.Second, 2},
+		{time.Second, 2},
+		{time.Second, 3},
+		{3 * time.Second, 4},
+	}
+	for i, step := range steps {
+		now = now.Add(step.after)
+		if err := do(); !errors.Is(err, errDown) {
+			t.Fatalf("step %d: got error %v, want %v", i, err, errDown)
+		}
+		if n := calls.Load(); n != step.calls {
+			t.Fatalf("step %d: got %d calls, want %d", i, n, step.calls)
+		}
+	}
+}
+
+func TestGroup_Timeout(t *testing.T) {
+	g := Group[int]{Timeout: 10 * time.Millisecond}
+	_, err := g.Do(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}